@@ -0,0 +1,464 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sudarshan/indexer/internal/config"
+	"github.com/sudarshan/indexer/internal/indexer"
+	"github.com/sudarshan/indexer/internal/logging"
+)
+
+func newPhase1Cmd() *cobra.Command {
+	var limit int
+	var reindexAll bool
+
+	cmd := &cobra.Command{
+		Use:   "phase1",
+		Short: "Run Phase 1: fetch documents and generate embeddings (cached)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPhase1(cmd.Context(), resolvedCfg, limit, reindexAll, quiet)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit number of documents (0 = all)")
+	cmd.Flags().BoolVar(&reindexAll, "reindex-all", false, "Reindex all documents (ignore existing IDs)")
+	return cmd
+}
+
+func newPhase2Cmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "phase2",
+		Short: "Run Phase 2: index to the search backend and update MongoDB (from cache)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPhase2(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newRunCmd() *cobra.Command {
+	var limit int
+	var reindexAll bool
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run both phases sequentially",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoth(cmd.Context(), resolvedCfg, limit, reindexAll, quiet)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit number of documents (0 = all)")
+	cmd.Flags().BoolVar(&reindexAll, "reindex-all", false, "Reindex all documents (ignore existing IDs)")
+	return cmd
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show cache status and search backend health",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showStatus(resolvedCfg, quiet)
+		},
+	}
+}
+
+func newCleanCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clean",
+		Short: "Clear the Phase 1 cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cleanCache(resolvedCfg, quiet)
+		},
+	}
+}
+
+func newCreateIndexCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-index",
+		Short: "Create the OpenSearch index",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createIndex(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newReindexFullCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex-full",
+		Short: "Zero-downtime reindex: build a new index generation, verify doc count, swap alias",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReindexFull(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newReindexMappingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex-mapping",
+		Short: "Zero-downtime mapping migration: copy documents into the current mapping version via _reindex, swap alias",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReindexMapping(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newListVersionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-versions",
+		Short: "List index generations left on the cluster by reindex-full",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listIndexVersions(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newRollbackCmd() *cobra.Command {
+	var toVersion string
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Point the index alias back at a prior generation (see list-versions)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(cmd.Context(), resolvedCfg, toVersion, quiet)
+		},
+	}
+	cmd.Flags().StringVar(&toVersion, "to", "", "Target generation name (see list-versions)")
+	return cmd
+}
+
+func newWarmCacheCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "warm-cache",
+		Short: "Pre-populate the embedding disk cache from MongoDB (no OpenSearch writes)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWarmCache(cmd.Context(), resolvedCfg, limit, quiet)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Limit number of documents (0 = all)")
+	return cmd
+}
+
+func newHealthzCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "healthz",
+		Short: "Serve the embedding circuit breaker state over HTTP for orchestration probes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHealthz(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newHealthCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "health",
+		Short: "Check search backend availability once and exit (use in readiness probes)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHealth(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newWatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Watch MongoDB for changes and index them to OpenSearch in real time",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(cmd.Context(), resolvedCfg, quiet)
+		},
+	}
+}
+
+func newSearchCmd() *cobra.Command {
+	var query string
+	var topK int
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Run an ad-hoc hybrid (BM25 + k-NN) query against the search backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(cmd.Context(), resolvedCfg, query, topK, quiet)
+		},
+	}
+	cmd.Flags().StringVar(&query, "query", "", "Query text (required)")
+	cmd.Flags().IntVar(&topK, "top-k", 0, "Results to return (0 = use config)")
+	return cmd
+}
+
+// Phase 1: Only needs MongoDB + Embedding (no OpenSearch)
+func runPhase1(ctx context.Context, cfg *config.Config, limit int, reindexAll, quiet bool) error {
+	idx, err := indexer.NewForPhase1(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.Phase1FetchAndEmbed(ctx, limit, reindexAll); err != nil {
+		return fmt.Errorf("phase 1 failed: %w", err)
+	}
+	return nil
+}
+
+// Phase 2: Only needs MongoDB + search backend (no Embedding)
+func runPhase2(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForPhase2(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.Phase2IndexAndUpdate(ctx); err != nil {
+		return fmt.Errorf("phase 2 failed: %w", err)
+	}
+	return nil
+}
+
+// Run both: Needs everything. Iterates cfg.TenantIDs (serially, or up to
+// cfg.TenantParallelism at once) when multi-tenancy is configured; runs a
+// single untenanted pass otherwise.
+func runBoth(ctx context.Context, cfg *config.Config, limit int, reindexAll, quiet bool) error {
+	if err := indexer.RunAllTenants(ctx, cfg, quiet, limit, reindexAll); err != nil {
+		return fmt.Errorf("indexing failed: %w", err)
+	}
+	return nil
+}
+
+// Status: cache plus a best-effort search backend connection for cluster health
+func showStatus(cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForStatus(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	idx.CacheStatus()
+	return nil
+}
+
+// Clean: Only needs cache
+func cleanCache(cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewCacheOnly(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.ClearCache(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// Create index: Needs OpenSearch only
+func createIndex(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForPhase2(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.CreateIndex(ctx); err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	return nil
+}
+
+// Migrate only: Needs MongoDB alone; NewMongoOnly's NewClient call has
+// already applied pending migrations by the time it returns.
+func runMigrateOnly(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewMongoOnly(cfg, quiet)
+	if err != nil {
+		return fmt.Errorf("schema migration failed: %w", err)
+	}
+	defer idx.Close()
+
+	logging.FromContext(ctx).Info("schema migrations up to date")
+	return nil
+}
+
+// Full reindex: Needs everything
+func runReindexFull(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.New(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.ReindexFull(ctx); err != nil {
+		return fmt.Errorf("full reindex failed: %w", err)
+	}
+	return nil
+}
+
+// Reindex mapping: Needs OpenSearch only
+func runReindexMapping(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForPhase2(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.ReindexMapping(ctx); err != nil {
+		return fmt.Errorf("mapping reindex failed: %w", err)
+	}
+	return nil
+}
+
+// List index versions: Needs OpenSearch only
+func listIndexVersions(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForPhase2(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	versions, err := idx.ListIndexVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list index versions: %w", err)
+	}
+	if len(versions) == 0 {
+		fmt.Println("No index generations found")
+		return nil
+	}
+	for _, v := range versions {
+		fmt.Println(v)
+	}
+	return nil
+}
+
+// Rollback: Needs OpenSearch only
+func runRollback(ctx context.Context, cfg *config.Config, toVersion string, quiet bool) error {
+	if toVersion == "" {
+		return fmt.Errorf("rollback requires --to VERSION (see list-versions)")
+	}
+
+	idx, err := indexer.NewForPhase2(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.RollbackTo(ctx, toVersion); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+	return nil
+}
+
+// Warm cache: Only needs MongoDB + Embedding, same as Phase 1, but intended
+// for pre-populating the embedding disk cache ahead of a reindex without
+// touching OpenSearch.
+func runWarmCache(ctx context.Context, cfg *config.Config, limit int, quiet bool) error {
+	idx, err := indexer.NewForPhase1(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.Phase1FetchAndEmbed(ctx, limit, true); err != nil {
+		return fmt.Errorf("warm cache failed: %w", err)
+	}
+	return nil
+}
+
+// Watch: Needs everything, same as run, but stays up indexing changes in
+// real time instead of doing one batch pass.
+func runWatch(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.New(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	if err := idx.RunChangeStream(ctx); err != nil {
+		return fmt.Errorf("change stream watch failed: %w", err)
+	}
+	return nil
+}
+
+// Healthz: Only needs the embedding client; serves its circuit breaker state
+// over HTTP until the context is canceled.
+func runHealthz(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForPhase1(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	handler, ok := idx.EmbeddingHealthHandler()
+	if !ok {
+		return fmt.Errorf("embedding provider does not expose a health handler")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", handler)
+	server := &http.Server{Addr: cfg.HealthzAddr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logging.FromContext(ctx).Info("serving /healthz", "addr", cfg.HealthzAddr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("healthz server failed: %w", err)
+	}
+	return nil
+}
+
+// Health: Only needs the search backend (best-effort, like status); checks
+// availability once and exits non-zero if it's down, for container/orchestrator
+// readiness probes that want a single command instead of the long-running
+// healthz HTTP server.
+func runHealth(ctx context.Context, cfg *config.Config, quiet bool) error {
+	idx, err := indexer.NewForStatus(cfg, quiet, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	available, detail := idx.SearchBackendHealth()
+	fmt.Println(detail)
+	if !available {
+		return fmt.Errorf("search backend unavailable")
+	}
+	return nil
+}
+
+// Search: Only needs OpenSearch + embedding, for ad-hoc hybrid queries.
+func runSearch(ctx context.Context, cfg *config.Config, query string, topK int, quiet bool) error {
+	if query == "" {
+		return fmt.Errorf("search requires --query TEXT")
+	}
+
+	idx, err := indexer.NewForSearch(cfg, quiet)
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer idx.Close()
+
+	resp, err := idx.Search(ctx, query, topK)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+
+	if len(resp.Hits) == 0 {
+		fmt.Println("No results")
+		return nil
+	}
+	for i, hit := range resp.Hits {
+		fmt.Printf("%d. [%s] %s (score=%.4f, bm25_rank=%d, knn_rank=%d)\n",
+			i+1, hit.MongoID, hit.Source.Title, hit.Score, hit.BM25Rank, hit.KNNRank)
+		for field, fragments := range hit.Highlights {
+			for _, fragment := range fragments {
+				fmt.Printf("     %s: %s\n", field, fragment)
+			}
+		}
+	}
+	return nil
+}
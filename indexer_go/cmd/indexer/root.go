@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/sudarshan/indexer/internal/config"
+	"github.com/sudarshan/indexer/internal/logging"
+)
+
+// Flags and resolved config shared by every subcommand. Cobra's idiomatic
+// pattern for a single-binary CLI is package-level vars populated by
+// PersistentPreRunE and read by each subcommand's RunE, rather than
+// threading a context struct through every command constructor.
+var (
+	cfgFile   string
+	logFormat string
+	quiet     bool
+
+	resolvedCfg *config.Config
+)
+
+// newRootCmd builds the command tree and wires flags > env > config.yaml >
+// defaults precedence via viper: BindPFlag gives a flag top precedence when
+// the caller actually sets it, but falls through to env/file/defaults when
+// left at its zero value (see config.LoadWithViper).
+func newRootCmd() *cobra.Command {
+	v := viper.New()
+
+	root := &cobra.Command{
+		Use:   "indexer",
+		Short: "Research Document Indexer - Two Phase Architecture",
+		Long: `Research Document Indexer (Go) - Two Phase Architecture
+
+Fetches research documents from MongoDB, embeds them, and indexes them into
+OpenSearch (or an embedded Bleve index for local dev) in two decoupled
+phases: phase1 (fetch + embed, cached to disk) and phase2 (index the cache
+to the search backend and update MongoDB).`,
+		Example: `  indexer phase1 --limit 1000     # Fetch and embed first 1000 docs
+  indexer phase2                   # Index cached embeddings to the search backend
+  indexer run --reindex-all        # Full run, reindex everything
+  indexer status                   # Check cache status
+  indexer search --query "transformer attention mechanisms"`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadWithViper(v, cfgFile)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			resolvedCfg = cfg
+
+			logger := logging.NewFromFormat(logFormat)
+			ctx := logging.WithLogger(cmd.Context(), logger)
+			cmd.SetContext(ctx)
+
+			// --migrate-only short-circuits whatever command was given: just
+			// run pending schema migrations and exit, so operators can
+			// preflight a migration without also running the command's real
+			// work.
+			if migrateOnly, _ := cmd.Flags().GetBool("migrate-only"); migrateOnly {
+				if err := runMigrateOnly(ctx, cfg, quiet); err != nil {
+					return err
+				}
+				os.Exit(0)
+			}
+			return nil
+		},
+	}
+
+	pf := root.PersistentFlags()
+	pf.StringVar(&cfgFile, "config", "", "Path to a YAML config file (see config.example.yaml)")
+	pf.StringVar(&logFormat, "log-format", "text", "Structured log format: text or json")
+	pf.BoolVar(&quiet, "quiet", false, "Minimal output")
+	pf.String("output", "", "cli.CLI output format: plain, json, or auto (default: auto, picks json when stdout isn't a terminal)")
+	pf.Int("workers", 0, "Number of parallel workers (0 = use config)")
+	pf.Int("max-retries", 0, "Retries for retryable OpenSearch writes (0 = use config)")
+	pf.Int("flush-bytes", 0, "Bulk indexer flush threshold in bytes (0 = use config)")
+	pf.Int("flush-interval", 0, "Bulk indexer flush interval in milliseconds (0 = use config)")
+	pf.Int("bulk-workers", 0, "Concurrent bulk indexer flush workers (0 = use config)")
+	pf.Int("tenant-parallelism", 0, "Tenants to run at once when TENANT_IDS is set (0 = use config)")
+	pf.Int("migration-version", 0, "Stop schema migrations at version N instead of the latest (0 = latest)")
+	pf.Bool("migrate-only", false, "Run pending schema migrations, then exit without running the command")
+
+	bindConfigFlags(v, pf)
+
+	root.AddCommand(
+		newPhase1Cmd(),
+		newPhase2Cmd(),
+		newRunCmd(),
+		newStatusCmd(),
+		newCleanCmd(),
+		newCreateIndexCmd(),
+		newReindexFullCmd(),
+		newReindexMappingCmd(),
+		newListVersionsCmd(),
+		newRollbackCmd(),
+		newWarmCacheCmd(),
+		newHealthzCmd(),
+		newHealthCmd(),
+		newWatchCmd(),
+		newSearchCmd(),
+	)
+
+	return root
+}
+
+// bindConfigFlags binds the persistent override flags to the config.Config
+// fields they shadow, under the same viper keys LoadWithViper reads.
+func bindConfigFlags(v *viper.Viper, pf *pflag.FlagSet) {
+	_ = v.BindPFlag("num_workers", pf.Lookup("workers"))
+	_ = v.BindPFlag("output_format", pf.Lookup("output"))
+	_ = v.BindPFlag("max_retries", pf.Lookup("max-retries"))
+	_ = v.BindPFlag("bulk_flush_bytes", pf.Lookup("flush-bytes"))
+	_ = v.BindPFlag("bulk_flush_interval_ms", pf.Lookup("flush-interval"))
+	_ = v.BindPFlag("bulk_workers", pf.Lookup("bulk-workers"))
+	_ = v.BindPFlag("tenant_parallelism", pf.Lookup("tenant-parallelism"))
+	_ = v.BindPFlag("migration_target_version", pf.Lookup("migration-version"))
+}
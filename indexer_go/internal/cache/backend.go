@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// Backend is implemented by every cache storage strategy so Phase 1 and
+// Phase 2 can run against whichever one config selects without the indexer
+// package caring which it's talking to. FileBackend is the default,
+// single-host store; RedisBackend lets Phase 1 workers on multiple machines
+// cooperatively fill a shared cache while Phase 2 pods drain it; Manager
+// segments the on-disk store so large corpora resume after a crash without
+// reprocessing and without holding every entry in memory at once.
+type Backend interface {
+	// Load reads any previously persisted state into memory.
+	Load() error
+	// Save persists in-memory state. Backends that write through on every
+	// AddEntries call (e.g. Redis) treat this as a no-op.
+	Save() error
+
+	// AddEntries records newly computed entries.
+	AddEntries(entries []CacheEntry)
+	// IsProcessed reports whether a Mongo ID has already been cached.
+	IsProcessed(mongoID string) bool
+	// GetEntries returns all cached entries.
+	GetEntries() []CacheEntry
+	// Count returns the number of cached entries.
+	Count() int
+
+	// SetMetadata records cache-wide bookkeeping for this run.
+	SetMetadata(totalDocs int64, reindexAll bool)
+	// GetMetadata returns cache-wide bookkeeping.
+	GetMetadata() CacheMetadata
+
+	// Stats reports entry count and storage size, best-effort.
+	Stats() (entries int, sizeBytes int64, err error)
+	// Exists reports whether any cached state has been persisted.
+	Exists() bool
+	// Clear removes all cached state.
+	Clear() error
+}
+
+// NewBackend constructs the cache Backend selected by cfg.CacheBackend
+// ("file" by default).
+func NewBackend(cfg *config.Config) (Backend, error) {
+	switch cfg.CacheBackend {
+	case "", "file":
+		return NewFileBackend(cfg.CacheDir)
+	case "redis":
+		return NewRedisBackend(cfg)
+	case "segmented":
+		return NewManager(cfg)
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.CacheBackend)
+	}
+}
+
+// TenantCacheDir returns the cache directory NewBackendForTenant actually
+// uses for tenantID: baseDir unchanged for tenantID == "" (single-tenant),
+// otherwise baseDir/tenant-<tenantID>. Exported so callers that log or
+// otherwise report the cache location (e.g. indexer.New's startup log)
+// show the real, tenant-scoped path instead of the unscoped cfg.CacheDir.
+func TenantCacheDir(baseDir, tenantID string) string {
+	if tenantID == "" {
+		return baseDir
+	}
+	return filepath.Join(baseDir, "tenant-"+tenantID)
+}
+
+// NewBackendForTenant is NewBackend namespaced per tenant, so one shared
+// cache dir (or Redis instance) can serve many tenants without their
+// entries colliding. tenantID == "" (single-tenant) behaves exactly like
+// NewBackend. Otherwise it builds the backend against a shallow copy of cfg
+// with CacheDir/RedisKeyPrefix suffixed by the tenant ID, leaving the
+// caller's *config.Config untouched.
+func NewBackendForTenant(cfg *config.Config, tenantID string) (Backend, error) {
+	if tenantID == "" {
+		return NewBackend(cfg)
+	}
+
+	scoped := *cfg
+	scoped.CacheDir = TenantCacheDir(cfg.CacheDir, tenantID)
+	scoped.RedisKeyPrefix = cfg.RedisKeyPrefix + ":tenant-" + tenantID
+	return NewBackend(&scoped)
+}
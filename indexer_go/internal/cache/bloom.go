@@ -0,0 +1,68 @@
+package cache
+
+import "hash/fnv"
+
+// bloomBits and bloomHashes size the filter for a few million entries at
+// roughly a 1% false-positive rate, which is what Manager needs: a false
+// positive just costs a wasted segment load, never a wrong answer.
+const (
+	bloomBits   = 1 << 21 // ~2M bits (~256KB on disk)
+	bloomHashes = 7
+)
+
+// bloomFilter is a small, self-contained Bloom filter backed by a byte
+// slice, used by Manager to cheaply rule out "definitely not cached" before
+// paying to load a segment off disk. Two FNV hashes are combined (Kirsch-
+// Mitzenmacher) to derive bloomHashes independent bit positions without a
+// k-function library.
+type bloomFilter struct {
+	bits []byte
+	m    uint64
+	k    int
+}
+
+func newBloomFilter(bits uint64, k int) *bloomFilter {
+	return &bloomFilter{bits: make([]byte, (bits+7)/8), m: bits, k: k}
+}
+
+// bloomFromBytes wraps a previously persisted filter's bits. bits and k must
+// match what it was created with, which Manager always uses the package
+// constants for.
+func bloomFromBytes(raw []byte, bits uint64, k int) *bloomFilter {
+	return &bloomFilter{bits: raw, m: bits, k: k}
+}
+
+func (f *bloomFilter) Add(key string) {
+	for _, h := range f.positions(key) {
+		f.bits[h/8] |= 1 << (h % 8)
+	}
+}
+
+func (f *bloomFilter) MightContain(key string) bool {
+	for _, h := range f.positions(key) {
+		if f.bits[h/8]&(1<<(h%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bloomFilter) Bytes() []byte {
+	return f.bits
+}
+
+func (f *bloomFilter) positions(key string) []uint64 {
+	h1 := fnv.New64a()
+	h1.Write([]byte(key))
+	sum1 := h1.Sum64()
+
+	h2 := fnv.New64()
+	h2.Write([]byte(key))
+	sum2 := h2.Sum64()
+
+	positions := make([]uint64, f.k)
+	for i := 0; i < f.k; i++ {
+		positions[i] = (sum1 + uint64(i)*sum2) % f.m
+	}
+	return positions
+}
@@ -25,6 +25,7 @@ type CacheEntry struct {
 	CitationCount   int
 	ReferenceCount  int
 	Embedding       []float32
+	ContentHash     string // SHA-256 of the text sent for embedding, for dedup across generations
 	ProcessedAt     time.Time
 }
 
@@ -49,8 +50,10 @@ type CacheMetadata struct {
 	ReindexAll   bool
 }
 
-// Cache manages the intermediate cache file for embeddings
-type Cache struct {
+// FileBackend is the on-disk Backend: a single gob file per cache dir,
+// guarded by a mutex. Simple, but forces Phase 1 and Phase 2 onto the same
+// host and serializes concurrent writers through mu.
+type FileBackend struct {
 	dir          string
 	mu           sync.RWMutex
 	metadata     CacheMetadata
@@ -58,13 +61,13 @@ type Cache struct {
 	processedIDs map[string]bool // Quick lookup of processed MongoDB IDs
 }
 
-// NewCache creates a new cache instance
-func NewCache(cacheDir string) (*Cache, error) {
+// NewFileBackend creates a new on-disk cache backend rooted at cacheDir.
+func NewFileBackend(cacheDir string) (*FileBackend, error) {
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return nil, fmt.Errorf("create cache dir: %w", err)
 	}
 
-	c := &Cache{
+	c := &FileBackend{
 		dir:          cacheDir,
 		entries:      make([]CacheEntry, 0),
 		processedIDs: make(map[string]bool),
@@ -74,17 +77,17 @@ func NewCache(cacheDir string) (*Cache, error) {
 }
 
 // cacheFilePath returns the path to the cache file
-func (c *Cache) cacheFilePath() string {
+func (c *FileBackend) cacheFilePath() string {
 	return filepath.Join(c.dir, "embeddings.gob")
 }
 
 // metadataFilePath returns the path to the metadata file
-func (c *Cache) metadataFilePath() string {
+func (c *FileBackend) metadataFilePath() string {
 	return filepath.Join(c.dir, "metadata.gob")
 }
 
 // Load reads the cache from disk
-func (c *Cache) Load() error {
+func (c *FileBackend) Load() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -123,7 +126,7 @@ func (c *Cache) Load() error {
 }
 
 // Save writes the cache to disk
-func (c *Cache) Save() error {
+func (c *FileBackend) Save() error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -157,7 +160,7 @@ func (c *Cache) Save() error {
 }
 
 // AddEntry adds a new entry to the cache (thread-safe)
-func (c *Cache) AddEntry(entry CacheEntry) {
+func (c *FileBackend) AddEntry(entry CacheEntry) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -167,7 +170,7 @@ func (c *Cache) AddEntry(entry CacheEntry) {
 }
 
 // AddEntries adds multiple entries to the cache (thread-safe)
-func (c *Cache) AddEntries(entries []CacheEntry) {
+func (c *FileBackend) AddEntries(entries []CacheEntry) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -180,14 +183,14 @@ func (c *Cache) AddEntries(entries []CacheEntry) {
 }
 
 // IsProcessed checks if a document ID has already been processed
-func (c *Cache) IsProcessed(mongoID string) bool {
+func (c *FileBackend) IsProcessed(mongoID string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.processedIDs[mongoID]
 }
 
 // GetEntries returns all cached entries
-func (c *Cache) GetEntries() []CacheEntry {
+func (c *FileBackend) GetEntries() []CacheEntry {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -197,14 +200,14 @@ func (c *Cache) GetEntries() []CacheEntry {
 }
 
 // Count returns the number of cached entries
-func (c *Cache) Count() int {
+func (c *FileBackend) Count() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return len(c.entries)
 }
 
 // SetMetadata updates cache metadata
-func (c *Cache) SetMetadata(totalDocs int64, reindexAll bool) {
+func (c *FileBackend) SetMetadata(totalDocs int64, reindexAll bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -216,14 +219,14 @@ func (c *Cache) SetMetadata(totalDocs int64, reindexAll bool) {
 }
 
 // GetMetadata returns cache metadata
-func (c *Cache) GetMetadata() CacheMetadata {
+func (c *FileBackend) GetMetadata() CacheMetadata {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.metadata
 }
 
 // Clear removes all cache files
-func (c *Cache) Clear() error {
+func (c *FileBackend) Clear() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -239,13 +242,13 @@ func (c *Cache) Clear() error {
 }
 
 // Exists checks if cache files exist
-func (c *Cache) Exists() bool {
+func (c *FileBackend) Exists() bool {
 	_, err := os.Stat(c.cacheFilePath())
 	return err == nil
 }
 
 // Stats returns cache statistics
-func (c *Cache) Stats() (entries int, sizeBytes int64, err error) {
+func (c *FileBackend) Stats() (entries int, sizeBytes int64, err error) {
 	c.mu.RLock()
 	entries = len(c.entries)
 	c.mu.RUnlock()
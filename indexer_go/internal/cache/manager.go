@@ -0,0 +1,567 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// segmentDescriptor is the manifest's record of one segment file on disk.
+// Bucket is a fixed function of MongoID (see Manager.bucketFor), not an
+// insertion-order sequence number, so a lookup can go straight to the one
+// segment that could possibly hold a given ID instead of scanning every
+// segment.
+type segmentDescriptor struct {
+	Bucket     int // segment file is "segment-<Bucket>.gob"
+	EntryCount int
+	Checksum   string // sha256 of the segment file's bytes, as of the last flush
+}
+
+// manifest is persisted as manifest.gob: the index over every segment, plus
+// the same cache-wide bookkeeping FileBackend keeps in metadata.gob.
+type manifest struct {
+	Metadata    CacheMetadata
+	Segments    []segmentDescriptor
+	NumSegments int // fixed bucket count this cache was built with; see Manager.bucketFor
+}
+
+// Manager is a segmented, resumable cache.Backend: every entry is assigned
+// to one of a fixed number of buckets by hashing its MongoID
+// (Manager.bucketFor), and each bucket's entries live in their own segment
+// file instead of one big gob blob that gets rewritten whole on every Save.
+// Because bucket assignment is a pure function of the ID, IsProcessed never
+// has to guess which segment(s) might hold a given ID - it loads exactly
+// one.
+//
+// IsProcessed consults an on-disk Bloom filter first; only a probable hit
+// pays for loading that one segment into segCache, which then serves
+// subsequent lookups against the same bucket for free.
+type Manager struct {
+	dir string
+	mu  sync.RWMutex
+
+	man         manifest
+	bloom       *bloomFilter
+	numSegments int
+
+	pending      map[int][]CacheEntry // entries added since the last flush, by bucket
+	pendingCount int                  // total pending entries across all buckets; drives fsyncEveryN
+
+	fsyncEveryN int
+
+	segCache     map[int][]CacheEntry // on-disk segment entries loaded on demand, keyed by bucket
+	processedIDs map[string]struct{}  // confirmed membership among buckets consulted this run
+}
+
+// NewManager creates a segmented cache.Backend rooted at cfg.CacheDir,
+// selected via CACHE_BACKEND=segmented. cfg.CacheSegmentCount buckets are
+// used for a fresh cache; Load overrides this with whatever bucket count an
+// existing manifest was built with, since changing it would rehash every ID
+// into a different bucket than whatever's already on disk.
+func NewManager(cfg *config.Config) (*Manager, error) {
+	if err := os.MkdirAll(cfg.CacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+
+	numSegments := cfg.CacheSegmentCount
+	if numSegments <= 0 {
+		numSegments = 256
+	}
+	fsyncEvery := cfg.CacheSegmentFsyncEvery
+	if fsyncEvery <= 0 {
+		fsyncEvery = 500
+	}
+
+	return &Manager{
+		dir:          cfg.CacheDir,
+		fsyncEveryN:  fsyncEvery,
+		numSegments:  numSegments,
+		bloom:        newBloomFilter(bloomBits, bloomHashes),
+		pending:      make(map[int][]CacheEntry),
+		segCache:     make(map[int][]CacheEntry),
+		processedIDs: make(map[string]struct{}),
+	}, nil
+}
+
+// bucketFor hashes mongoID into a fixed segment bucket in [0, numSegments),
+// so every call in the life of this cache (and any resumed run against the
+// same manifest) routes the same ID to the same segment file.
+func (m *Manager) bucketFor(mongoID string) int {
+	return int(crc32.ChecksumIEEE([]byte(mongoID)) % uint32(m.numSegments))
+}
+
+func (m *Manager) manifestPath() string { return filepath.Join(m.dir, "manifest.gob") }
+func (m *Manager) bloomPath() string    { return filepath.Join(m.dir, "bloom.dat") }
+func (m *Manager) segmentPath(bucket int) string {
+	return filepath.Join(m.dir, fmt.Sprintf("segment-%04d.gob", bucket))
+}
+
+// Load reads the manifest and Bloom filter. If a manifest already exists,
+// its NumSegments (the bucket count it was built with) takes over from
+// whatever NewManager was configured with, so IDs keep hashing to the same
+// buckets they were written to.
+func (m *Manager) Load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := os.ReadFile(m.manifestPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("open manifest: %w", err)
+	}
+	var man manifest
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&man); err != nil {
+		return fmt.Errorf("decode manifest: %w", err)
+	}
+	m.man = man
+	if man.NumSegments > 0 {
+		m.numSegments = man.NumSegments
+	}
+
+	if bloomData, err := os.ReadFile(m.bloomPath()); err == nil {
+		m.bloom = bloomFromBytes(bloomData, bloomBits, bloomHashes)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("open bloom filter: %w", err)
+	}
+
+	return nil
+}
+
+// Save flushes every bucket with unflushed entries and persists the
+// manifest, so a Save call (like FileBackend's) always leaves the cache in
+// a resumable state.
+func (m *Manager) Save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.man.Metadata.LastModified = time.Now()
+	if err := m.flushPendingLocked(); err != nil {
+		return err
+	}
+	return m.persistManifestLocked()
+}
+
+// AddEntries buckets each entry by MongoID, buffers it in pending, updates
+// the Bloom filter and processed-ID set, and flushes every bucket with
+// unflushed entries to disk every fsyncEveryN entries so a crash loses at
+// most that many unflushed entries.
+func (m *Manager) AddEntries(entries []CacheEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, e := range entries {
+		e.ProcessedAt = now
+		id := e.MongoID.Hex()
+		bucket := m.bucketFor(id)
+
+		m.pending[bucket] = append(m.pending[bucket], e)
+		m.bloom.Add(id)
+		m.processedIDs[id] = struct{}{}
+		m.pendingCount++
+	}
+
+	if m.pendingCount >= m.fsyncEveryN {
+		if err := m.flushPendingLocked(); err != nil {
+			log.Printf("  Warning: failed to flush cache segments: %v", err)
+			return
+		}
+		if err := m.persistManifestLocked(); err != nil {
+			log.Printf("  Warning: failed to persist cache manifest: %v", err)
+		}
+	}
+}
+
+// IsProcessed checks the in-memory set first, then the Bloom filter. A
+// Bloom miss is a definite "no"; a Bloom hit loads only the one segment
+// bucketFor(mongoID) maps to (plus anything still pending for that bucket)
+// and caches it in segCache so later lookups against the same bucket are
+// free.
+func (m *Manager) IsProcessed(mongoID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.processedIDs[mongoID]; ok {
+		return true
+	}
+	if !m.bloom.MightContain(mongoID) {
+		return false
+	}
+
+	bucket := m.bucketFor(mongoID)
+	for _, e := range m.pending[bucket] {
+		m.processedIDs[e.MongoID.Hex()] = struct{}{}
+	}
+
+	if _, loaded := m.segCache[bucket]; !loaded {
+		entries, err := m.readSegment(bucket)
+		if err != nil {
+			log.Printf("  Warning: failed to load cache segment %04d: %v", bucket, err)
+		} else {
+			m.segCache[bucket] = entries
+			for _, e := range entries {
+				m.processedIDs[e.MongoID.Hex()] = struct{}{}
+			}
+		}
+	}
+
+	_, ok := m.processedIDs[mongoID]
+	return ok
+}
+
+// GetEntries materializes every entry across every bucket. Kept for Backend
+// compatibility; Stream is the memory-flat alternative for large corpora.
+func (m *Manager) GetEntries() []CacheEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]CacheEntry, 0, m.countLocked())
+	for _, desc := range m.man.Segments {
+		entries, ok := m.segCache[desc.Bucket]
+		if !ok {
+			var err error
+			entries, err = m.readSegment(desc.Bucket)
+			if err != nil {
+				log.Printf("  Warning: failed to load cache segment %04d: %v", desc.Bucket, err)
+				continue
+			}
+			m.segCache[desc.Bucket] = entries
+		}
+		result = append(result, entries...)
+	}
+	for _, pending := range m.pending {
+		result = append(result, pending...)
+	}
+	return result
+}
+
+// Stream reads segments one at a time and yields entries matching filter
+// (or every entry if filter is nil), so callers never have to hold the
+// whole corpus in memory the way GetEntries does. The channel is closed
+// when every segment has been read or ctx is canceled, whichever comes
+// first.
+func (m *Manager) Stream(ctx context.Context, filter func(CacheEntry) bool) <-chan CacheEntry {
+	out := make(chan CacheEntry)
+
+	go func() {
+		defer close(out)
+
+		m.mu.RLock()
+		buckets := make([]int, len(m.man.Segments))
+		for i, desc := range m.man.Segments {
+			buckets[i] = desc.Bucket
+		}
+		pending := make([]CacheEntry, 0, m.pendingCount)
+		for _, p := range m.pending {
+			pending = append(pending, p...)
+		}
+		m.mu.RUnlock()
+
+		emit := func(e CacheEntry) bool {
+			if filter != nil && !filter(e) {
+				return true
+			}
+			select {
+			case out <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, bucket := range buckets {
+			entries, err := m.readSegment(bucket)
+			if err != nil {
+				log.Printf("  Warning: failed to stream cache segment %04d: %v", bucket, err)
+				continue
+			}
+			for _, e := range entries {
+				if !emit(e) {
+					return
+				}
+			}
+		}
+		for _, e := range pending {
+			if !emit(e) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Count returns the number of cached entries across all segments.
+func (m *Manager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.countLocked()
+}
+
+func (m *Manager) countLocked() int {
+	total := m.pendingCount
+	for _, desc := range m.man.Segments {
+		total += desc.EntryCount
+	}
+	return total
+}
+
+// SetMetadata updates cache-wide bookkeeping for this run.
+func (m *Manager) SetMetadata(totalDocs int64, reindexAll bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.man.Metadata.CreatedAt.IsZero() {
+		m.man.Metadata.CreatedAt = time.Now()
+	}
+	m.man.Metadata.TotalDocs = totalDocs
+	m.man.Metadata.ReindexAll = reindexAll
+}
+
+// GetMetadata returns cache-wide bookkeeping.
+func (m *Manager) GetMetadata() CacheMetadata {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.man.Metadata
+}
+
+// Stats reports the total entry count and on-disk size across all segment
+// files plus the manifest.
+func (m *Manager) Stats() (entries int, sizeBytes int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries = m.countLocked()
+	for _, desc := range m.man.Segments {
+		if info, statErr := os.Stat(m.segmentPath(desc.Bucket)); statErr == nil {
+			sizeBytes += info.Size()
+		}
+	}
+	if info, statErr := os.Stat(m.manifestPath()); statErr == nil {
+		sizeBytes += info.Size()
+	}
+	return entries, sizeBytes, nil
+}
+
+// Exists reports whether a manifest has ever been persisted.
+func (m *Manager) Exists() bool {
+	_, err := os.Stat(m.manifestPath())
+	return err == nil
+}
+
+// Clear removes every segment file, the manifest, and the Bloom filter.
+func (m *Manager) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, desc := range m.man.Segments {
+		os.Remove(m.segmentPath(desc.Bucket))
+	}
+	os.Remove(m.manifestPath())
+	os.Remove(m.bloomPath())
+
+	m.man = manifest{}
+	m.bloom = newBloomFilter(bloomBits, bloomHashes)
+	m.pending = make(map[int][]CacheEntry)
+	m.pendingCount = 0
+	m.segCache = make(map[int][]CacheEntry)
+	m.processedIDs = make(map[string]struct{})
+
+	return nil
+}
+
+// Compact rewrites every segment, keeping only the newest entry per Mongo
+// ID (duplicates accumulate when a document is re-embedded without
+// clearing the cache first). Survivors are regrouped by bucketFor(id) - the
+// same assignment AddEntries uses - so compaction can never move an ID to a
+// different segment than IsProcessed would look in. The new manifest and
+// Bloom filter are written before the old segment files are removed, so a
+// crash mid-compaction leaves the old data intact rather than
+// half-deleted.
+func (m *Manager) Compact() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	latest := make(map[string]CacheEntry)
+	order := make([]string, 0, m.countLocked())
+	collect := func(e CacheEntry) {
+		id := e.MongoID.Hex()
+		prev, seen := latest[id]
+		if !seen {
+			order = append(order, id)
+		}
+		if !seen || e.ProcessedAt.After(prev.ProcessedAt) {
+			latest[id] = e
+		}
+	}
+
+	staleSegmentPaths := make([]string, 0, len(m.man.Segments))
+	for _, desc := range m.man.Segments {
+		entries, err := m.readSegment(desc.Bucket)
+		if err != nil {
+			return fmt.Errorf("read segment %04d for compaction: %w", desc.Bucket, err)
+		}
+		for _, e := range entries {
+			collect(e)
+		}
+		staleSegmentPaths = append(staleSegmentPaths, m.segmentPath(desc.Bucket))
+	}
+	for _, pending := range m.pending {
+		for _, e := range pending {
+			collect(e)
+		}
+	}
+
+	byBucket := make(map[int][]CacheEntry)
+	newBloom := newBloomFilter(bloomBits, bloomHashes)
+	for _, id := range order {
+		bucket := m.bucketFor(id)
+		byBucket[bucket] = append(byBucket[bucket], latest[id])
+		newBloom.Add(id)
+	}
+
+	newMan := manifest{Metadata: m.man.Metadata, NumSegments: m.numSegments}
+	for bucket, entries := range byBucket {
+		checksum, err := m.writeSegment(bucket, entries)
+		if err != nil {
+			return fmt.Errorf("write compacted segment %04d: %w", bucket, err)
+		}
+		newMan.Segments = append(newMan.Segments, segmentDescriptor{
+			Bucket: bucket, EntryCount: len(entries), Checksum: checksum,
+		})
+	}
+
+	m.man = newMan
+	m.bloom = newBloom
+	m.pending = make(map[int][]CacheEntry)
+	m.pendingCount = 0
+	m.segCache = byBucket
+	m.processedIDs = make(map[string]struct{}, len(order))
+	for _, id := range order {
+		m.processedIDs[id] = struct{}{}
+	}
+
+	if err := m.persistManifestLocked(); err != nil {
+		return err
+	}
+
+	for _, p := range staleSegmentPaths {
+		os.Remove(p)
+	}
+	return nil
+}
+
+func (m *Manager) readSegment(bucket int) ([]CacheEntry, error) {
+	data, err := os.ReadFile(m.segmentPath(bucket))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []CacheEntry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode segment %04d: %w", bucket, err)
+	}
+	return entries, nil
+}
+
+// writeSegment encodes entries and fsyncs them to segment-<bucket>.gob,
+// returning the sha256 checksum recorded in the manifest so a later Load
+// could detect a truncated or corrupted segment file.
+func (m *Manager) writeSegment(bucket int, entries []CacheEntry) (checksum string, err error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		return "", fmt.Errorf("encode segment %04d: %w", bucket, err)
+	}
+
+	f, err := os.Create(m.segmentPath(bucket))
+	if err != nil {
+		return "", fmt.Errorf("create segment %04d: %w", bucket, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return "", fmt.Errorf("write segment %04d: %w", bucket, err)
+	}
+	if err := f.Sync(); err != nil {
+		return "", fmt.Errorf("fsync segment %04d: %w", bucket, err)
+	}
+
+	return sha256Hex(buf.Bytes()), nil
+}
+
+// flushPendingLocked merges each bucket's pending entries into that
+// bucket's on-disk segment (read-modify-write, since writeSegment always
+// rewrites the whole file) and updates the manifest and segCache to match.
+func (m *Manager) flushPendingLocked() error {
+	if len(m.pending) == 0 {
+		return nil
+	}
+
+	for bucket, added := range m.pending {
+		existing, err := m.readSegment(bucket)
+		if err != nil {
+			return fmt.Errorf("read segment %04d: %w", bucket, err)
+		}
+		merged := append(existing, added...)
+
+		checksum, err := m.writeSegment(bucket, merged)
+		if err != nil {
+			return err
+		}
+		m.upsertSegmentDescriptorLocked(segmentDescriptor{
+			Bucket: bucket, EntryCount: len(merged), Checksum: checksum,
+		})
+		m.segCache[bucket] = merged
+	}
+
+	m.pending = make(map[int][]CacheEntry)
+	m.pendingCount = 0
+	m.man.NumSegments = m.numSegments
+	return nil
+}
+
+func (m *Manager) upsertSegmentDescriptorLocked(desc segmentDescriptor) {
+	for i, s := range m.man.Segments {
+		if s.Bucket == desc.Bucket {
+			m.man.Segments[i] = desc
+			return
+		}
+	}
+	m.man.Segments = append(m.man.Segments, desc)
+}
+
+func (m *Manager) persistManifestLocked() error {
+	m.man.NumSegments = m.numSegments
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m.man); err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := os.WriteFile(m.manifestPath(), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return os.WriteFile(m.bloomPath(), m.bloom.Bytes(), 0644)
+}
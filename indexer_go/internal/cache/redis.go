@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// RedisBackend stores cache state in Redis instead of a local file, so
+// Phase 1 workers on multiple machines can cooperatively fill the cache
+// while Phase 2 pods drain it, with no shared filesystem required. Each
+// entry is a gob-encoded blob under a key hashed by Mongo ID; a Redis SET
+// tracks processed Mongo IDs for an O(1) IsProcessed that never needs a
+// mutex.
+type RedisBackend struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBackend connects to Redis using cfg and verifies the connection
+// with a PING before returning.
+func NewRedisBackend(cfg *config.Config) (*RedisBackend, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("redis ping: %w", err)
+	}
+
+	return &RedisBackend{client: client, prefix: cfg.RedisKeyPrefix}, nil
+}
+
+func (r *RedisBackend) entryKey(mongoID string) string { return r.prefix + ":entry:" + mongoID }
+func (r *RedisBackend) processedSetKey() string        { return r.prefix + ":processed" }
+func (r *RedisBackend) metadataKey() string            { return r.prefix + ":metadata" }
+
+// Load is a no-op: RedisBackend reads through to Redis on every call
+// instead of hydrating an in-memory copy, so there's nothing to load.
+func (r *RedisBackend) Load() error { return nil }
+
+// Save is a no-op: AddEntries and SetMetadata already write through.
+func (r *RedisBackend) Save() error { return nil }
+
+// AddEntries writes each entry's blob and marks its Mongo ID processed in a
+// single pipelined round trip.
+func (r *RedisBackend) AddEntries(entries []CacheEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	pipe := r.client.Pipeline()
+	for i := range entries {
+		entries[i].ProcessedAt = now
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(entries[i]); err != nil {
+			log.Printf("redis cache: encode entry %s: %v", entries[i].MongoID.Hex(), err)
+			continue
+		}
+		pipe.Set(ctx, r.entryKey(entries[i].MongoID.Hex()), buf.Bytes(), 0)
+		pipe.SAdd(ctx, r.processedSetKey(), entries[i].MongoID.Hex())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("redis cache: add entries: %v", err)
+	}
+}
+
+// IsProcessed checks set membership, an O(1) Redis operation that needs no
+// local lock.
+func (r *RedisBackend) IsProcessed(mongoID string) bool {
+	ok, err := r.client.SIsMember(context.Background(), r.processedSetKey(), mongoID).Result()
+	if err != nil {
+		log.Printf("redis cache: is processed: %v", err)
+		return false
+	}
+	return ok
+}
+
+// GetEntries fetches and decodes every entry blob for the processed set.
+// Phase 2 is expected to call this once per run, not in a hot loop.
+func (r *RedisBackend) GetEntries() []CacheEntry {
+	ctx := context.Background()
+	ids, err := r.client.SMembers(ctx, r.processedSetKey()).Result()
+	if err != nil {
+		log.Printf("redis cache: list processed ids: %v", err)
+		return nil
+	}
+
+	entries := make([]CacheEntry, 0, len(ids))
+	for _, id := range ids {
+		data, err := r.client.Get(ctx, r.entryKey(id)).Bytes()
+		if err != nil {
+			continue
+		}
+		var entry CacheEntry
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+			log.Printf("redis cache: decode entry %s: %v", id, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Count returns the processed set's cardinality.
+func (r *RedisBackend) Count() int {
+	n, err := r.client.SCard(context.Background(), r.processedSetKey()).Result()
+	if err != nil {
+		log.Printf("redis cache: count: %v", err)
+		return 0
+	}
+	return int(n)
+}
+
+// SetMetadata merges totalDocs/reindexAll into the stored metadata blob.
+func (r *RedisBackend) SetMetadata(totalDocs int64, reindexAll bool) {
+	meta := r.GetMetadata()
+	if meta.CreatedAt.IsZero() {
+		meta.CreatedAt = time.Now()
+	}
+	meta.TotalDocs = totalDocs
+	meta.ReindexAll = reindexAll
+	meta.LastModified = time.Now()
+	meta.Version = 1
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(meta); err != nil {
+		log.Printf("redis cache: encode metadata: %v", err)
+		return
+	}
+	if err := r.client.Set(context.Background(), r.metadataKey(), buf.Bytes(), 0).Err(); err != nil {
+		log.Printf("redis cache: set metadata: %v", err)
+	}
+}
+
+// GetMetadata returns the stored metadata blob, or a zero value if none has
+// been written yet.
+func (r *RedisBackend) GetMetadata() CacheMetadata {
+	data, err := r.client.Get(context.Background(), r.metadataKey()).Bytes()
+	if err != nil {
+		return CacheMetadata{}
+	}
+	var meta CacheMetadata
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&meta); err != nil {
+		return CacheMetadata{}
+	}
+	return meta
+}
+
+// Stats reports the entry count. Unlike FileBackend, it doesn't report a
+// byte size: getting a true total would mean a MEMORY USAGE round trip per
+// key, which isn't worth it just for a status display.
+func (r *RedisBackend) Stats() (entries int, sizeBytes int64, err error) {
+	return r.Count(), 0, nil
+}
+
+// Exists reports whether any documents have been cached yet.
+func (r *RedisBackend) Exists() bool {
+	return r.Count() > 0
+}
+
+// Clear removes every entry blob, the processed set, and the metadata blob.
+func (r *RedisBackend) Clear() error {
+	ctx := context.Background()
+	ids, err := r.client.SMembers(ctx, r.processedSetKey()).Result()
+	if err != nil {
+		return fmt.Errorf("list processed ids: %w", err)
+	}
+
+	pipe := r.client.Pipeline()
+	for _, id := range ids {
+		pipe.Del(ctx, r.entryKey(id))
+	}
+	pipe.Del(ctx, r.processedSetKey())
+	pipe.Del(ctx, r.metadataKey())
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"os"
+	"time"
+)
+
+// Emitter renders the events CLI produces onto some output. PlainEmitter is
+// the original Docker-style terminal stream; JSONEmitter writes newline-
+// delimited JSON events instead, modeled on BuildKit's structured progress
+// stream, so CI systems, dashboards, or a TUI wrapper can consume an
+// indexer run programmatically instead of scraping stdout. New's --output
+// flag selects which one a CLI wraps.
+type Emitter interface {
+	PhaseStart(name string)
+	PhaseEnd(name string, duration time.Duration)
+	Step(current, total int, description string)
+	Running(message string)
+	Info(message string)
+	Success(message string)
+	Done()
+	Warning(message string)
+	Error(message string)
+	Progress(current, total int64, rate float64, eta time.Duration)
+	RateProgress(label string, rate float64, inflight, errors int64, throttled bool)
+	ProgressDone()
+	Summary(title string, items map[string]string)
+	CacheStatus(exists bool, entries int, sizeBytes int64, metadata map[string]string)
+}
+
+// newEmitter resolves the --output flag into an Emitter. "plain" always
+// gives the Docker-style stream and "json" always gives the structured
+// stream; "auto" (the default, and any other value) picks JSON when stdout
+// isn't a terminal, e.g. piped to a log collector or running under CI, and
+// the Docker-style stream otherwise.
+func newEmitter(output string, quiet bool) Emitter {
+	switch output {
+	case "plain":
+		return newPlainEmitter(quiet)
+	case "json":
+		return newJSONEmitter()
+	default:
+		if isTerminal(os.Stdout) {
+			return newPlainEmitter(quiet)
+		}
+		return newJSONEmitter()
+	}
+}
+
+// isTerminal reports whether f is attached to a character device (a
+// terminal) rather than a pipe or regular file.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// jsonEmitter writes one JSON object per line, newline-delimited, modeled
+// on BuildKit's structured progress stream. Fields are written out, rather
+// than routed through a shared envelope type, so each event's shape stays
+// obvious from its constructor here. Calls are serialized behind a mutex
+// since Phase 1/2 drive this concurrently from the mongo streamer, the
+// embedding worker pool, and the bulk updater.
+type jsonEmitter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func newJSONEmitter() *jsonEmitter {
+	return &jsonEmitter{out: os.Stdout}
+}
+
+func (e *jsonEmitter) write(v any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(e.out, string(data))
+}
+
+func nowMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
+func (e *jsonEmitter) PhaseStart(name string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+		Ts   int64  `json:"ts"`
+	}{"phase_start", name, nowMillis()})
+}
+
+func (e *jsonEmitter) PhaseEnd(name string, duration time.Duration) {
+	e.write(struct {
+		Type       string `json:"type"`
+		Name       string `json:"name"`
+		DurationMs int64  `json:"duration_ms"`
+		Ts         int64  `json:"ts"`
+	}{"phase_end", name, duration.Milliseconds(), nowMillis()})
+}
+
+func (e *jsonEmitter) Step(current, total int, description string) {
+	e.write(struct {
+		Type    string `json:"type"`
+		Current int    `json:"current"`
+		Total   int    `json:"total"`
+		Desc    string `json:"desc"`
+		Ts      int64  `json:"ts"`
+	}{"step", current, total, description, nowMillis()})
+}
+
+func (e *jsonEmitter) Running(message string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Msg  string `json:"msg"`
+		Ts   int64  `json:"ts"`
+	}{"running", message, nowMillis()})
+}
+
+func (e *jsonEmitter) Info(message string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Msg  string `json:"msg"`
+		Ts   int64  `json:"ts"`
+	}{"info", message, nowMillis()})
+}
+
+func (e *jsonEmitter) Success(message string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Msg  string `json:"msg"`
+		Ts   int64  `json:"ts"`
+	}{"success", message, nowMillis()})
+}
+
+func (e *jsonEmitter) Done() {
+	e.write(struct {
+		Type string `json:"type"`
+		Ts   int64  `json:"ts"`
+	}{"done", nowMillis()})
+}
+
+func (e *jsonEmitter) Warning(message string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Msg  string `json:"msg"`
+		Ts   int64  `json:"ts"`
+	}{"warning", message, nowMillis()})
+}
+
+func (e *jsonEmitter) Error(message string) {
+	e.write(struct {
+		Type string `json:"type"`
+		Msg  string `json:"msg"`
+		Ts   int64  `json:"ts"`
+	}{"error", message, nowMillis()})
+}
+
+func (e *jsonEmitter) Progress(current, total int64, rate float64, eta time.Duration) {
+	e.write(struct {
+		Type    string  `json:"type"`
+		Current int64   `json:"current"`
+		Total   int64   `json:"total"`
+		Rate    float64 `json:"rate"`
+		EtaMs   int64   `json:"eta_ms"`
+		Ts      int64   `json:"ts"`
+	}{"progress", current, total, rate, eta.Milliseconds(), nowMillis()})
+}
+
+func (e *jsonEmitter) RateProgress(label string, rate float64, inflight, errors int64, throttled bool) {
+	e.write(struct {
+		Type      string  `json:"type"`
+		Label     string  `json:"label"`
+		Rate      float64 `json:"rate"`
+		Inflight  int64   `json:"inflight"`
+		Errors    int64   `json:"errors"`
+		Throttled bool    `json:"throttled"`
+		Ts        int64   `json:"ts"`
+	}{"rate_progress", label, rate, inflight, errors, throttled, nowMillis()})
+}
+
+func (e *jsonEmitter) ProgressDone() {
+	e.write(struct {
+		Type string `json:"type"`
+		Ts   int64  `json:"ts"`
+	}{"progress_done", nowMillis()})
+}
+
+func (e *jsonEmitter) Summary(title string, items map[string]string) {
+	e.write(struct {
+		Type  string            `json:"type"`
+		Title string            `json:"title"`
+		Items map[string]string `json:"items"`
+		Ts    int64             `json:"ts"`
+	}{"summary", title, items, nowMillis()})
+}
+
+func (e *jsonEmitter) CacheStatus(exists bool, entries int, sizeBytes int64, metadata map[string]string) {
+	e.write(struct {
+		Type      string            `json:"type"`
+		Exists    bool              `json:"exists"`
+		Entries   int               `json:"entries"`
+		SizeBytes int64             `json:"size_bytes"`
+		Metadata  map[string]string `json:"metadata,omitempty"`
+		Ts        int64             `json:"ts"`
+	}{"cache_status", exists, entries, sizeBytes, metadata, nowMillis()})
+}
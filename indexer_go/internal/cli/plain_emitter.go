@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// plainEmitter reproduces the original Docker-style pretty-printed output:
+// "Step N/M : description", " ---> " status lines, and an in-place
+// progress bar.
+type plainEmitter struct {
+	quiet bool
+}
+
+func newPlainEmitter(quiet bool) *plainEmitter {
+	return &plainEmitter{quiet: quiet}
+}
+
+func (e *plainEmitter) PhaseStart(name string) {
+	if e.quiet {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("Sending context to %s...\n", name)
+}
+
+func (e *plainEmitter) PhaseEnd(name string, duration time.Duration) {
+	if e.quiet {
+		return
+	}
+	fmt.Printf("Successfully completed in %s\n", formatDuration(duration))
+}
+
+func (e *plainEmitter) Step(current, total int, description string) {
+	if e.quiet {
+		return
+	}
+	fmt.Printf("Step %d/%d : %s\n", current, total, description)
+}
+
+func (e *plainEmitter) Running(message string) {
+	if e.quiet {
+		return
+	}
+	id := generateShortID()
+	fmt.Printf(" ---> Running in %s\n", id)
+	fmt.Printf("      %s\n", message)
+}
+
+func (e *plainEmitter) Info(message string) {
+	if e.quiet {
+		return
+	}
+	fmt.Printf(" ---> %s\n", message)
+}
+
+func (e *plainEmitter) Success(message string) {
+	if e.quiet {
+		return
+	}
+	fmt.Printf(" ---> %s\n", message)
+}
+
+func (e *plainEmitter) Done() {
+	if e.quiet {
+		return
+	}
+	fmt.Printf(" ---> %s\n", generateShortID())
+}
+
+func (e *plainEmitter) Error(message string) {
+	fmt.Printf("ERROR: %s\n", message)
+}
+
+func (e *plainEmitter) Warning(message string) {
+	if e.quiet {
+		return
+	}
+	fmt.Printf(" ---> [WARNING] %s\n", message)
+}
+
+func (e *plainEmitter) Progress(current, total int64, rate float64, eta time.Duration) {
+	if e.quiet || total == 0 {
+		return
+	}
+
+	percent := float64(current) / float64(total) * 100
+
+	rateStr := "--/s"
+	if rate > 0 {
+		rateStr = fmt.Sprintf("%.1f/s", rate)
+	}
+
+	fmt.Printf("\r ---> Downloading: [%s] %d/%d %.1f%% %s eta %s    ",
+		progressBar(percent), current, total, percent, rateStr, formatDuration(eta))
+}
+
+func (e *plainEmitter) RateProgress(label string, rate float64, inflight, errors int64, throttled bool) {
+	if e.quiet {
+		return
+	}
+
+	status := ""
+	if throttled {
+		status = " (throttled)"
+	}
+	fmt.Printf("\r ---> %s: %.1f/s, %d inflight, %d errors%s    ", label, rate, inflight, errors, status)
+}
+
+func (e *plainEmitter) ProgressDone() {
+	if e.quiet {
+		return
+	}
+	fmt.Println()
+}
+
+func (e *plainEmitter) Summary(title string, items map[string]string) {
+	if e.quiet {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Successfully completed: %s\n", title)
+
+	for k, v := range items {
+		fmt.Printf(" - %s: %s\n", k, v)
+	}
+}
+
+func (e *plainEmitter) CacheStatus(exists bool, entries int, sizeBytes int64, metadata map[string]string) {
+	if e.quiet {
+		return
+	}
+
+	fmt.Println()
+	if !exists {
+		fmt.Println("Cache: empty")
+		return
+	}
+
+	fmt.Printf("Cache: %d entries (%s)\n", entries, formatBytes(sizeBytes))
+	for k, v := range metadata {
+		fmt.Printf(" - %s: %s\n", k, v)
+	}
+}
+
+// progressBar creates a simple progress bar
+func progressBar(percent float64) string {
+	width := 20
+	filled := int(float64(width) * percent / 100)
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("=", filled) + ">" + strings.Repeat(" ", width-filled)
+}
+
+// generateShortID generates a fake Docker-style short ID
+func generateShortID() string {
+	// Use current time to generate a pseudo-random looking ID
+	t := time.Now().UnixNano()
+	chars := "0123456789abcdef"
+	result := make([]byte, 12)
+	for i := range result {
+		result[i] = chars[(t>>(i*4))&0xf]
+	}
+	return string(result)
+}
+
+// formatDuration formats a duration in a human-readable way
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	if d < time.Hour {
+		m := int(d.Minutes())
+		s := int(d.Seconds()) % 60
+		return fmt.Sprintf("%dm%ds", m, s)
+	}
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// formatBytes formats bytes in human-readable format
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
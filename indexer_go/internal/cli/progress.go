@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"sync"
+	"time"
+)
+
+// RateStats is a snapshot of an internal/ratelimit.Limiter's state, set on a
+// Progress via SetRateStats so CLI.Progress can render "writes: 230/s
+// (throttled)" instead of computing its own rate/ETA from Current.
+type RateStats struct {
+	Label     string // e.g. "writes" or "reads"
+	Rate      float64
+	Inflight  int64
+	Errors    int64
+	Throttled bool
+}
+
+// Progress tracks progress of a long-running operation. Indexer phases
+// update it from several worker goroutines while a ticker goroutine reads
+// it concurrently to drive CLI.Progress, so Current is kept behind a mutex
+// instead of being a plain field.
+type Progress struct {
+	Total     int64
+	StartTime time.Time
+
+	mu        sync.Mutex
+	current   int64
+	rateStats *RateStats
+}
+
+// NewProgress creates a new progress tracker
+func NewProgress(total int64) *Progress {
+	return &Progress{
+		Total:     total,
+		StartTime: time.Now(),
+	}
+}
+
+// Update updates progress by incrementing current count
+func (p *Progress) Update(delta int64) {
+	p.mu.Lock()
+	p.current += delta
+	p.mu.Unlock()
+}
+
+// Set sets the current progress value
+func (p *Progress) Set(current int64) {
+	p.mu.Lock()
+	p.current = current
+	p.mu.Unlock()
+}
+
+// Current returns the current progress count.
+func (p *Progress) Current() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// SetRateStats attaches a rate-limiter snapshot driving this progress, e.g.
+// from mongodb.Client.RateStats. Pass nil to fall back to the plain
+// current/total ETA rendering.
+func (p *Progress) SetRateStats(stats *RateStats) {
+	p.mu.Lock()
+	p.rateStats = stats
+	p.mu.Unlock()
+}
+
+// RateStats returns the rate-limiter snapshot last attached via
+// SetRateStats, or nil if none was set.
+func (p *Progress) RateStats() *RateStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rateStats
+}
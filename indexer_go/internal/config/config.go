@@ -15,7 +15,15 @@ type Config struct {
 	MongoCollection   string
 	MongoMaxPoolSize  int // Connection pool limit for free tier
 	MongoFetchDelayMs int // Delay between fetches to avoid overwhelming free tier
-	MongoBulkDelayMs  int // Delay between bulk writes
+
+	// Adaptive rate limiting (AIMD) shared by StreamDocuments reads and
+	// BulkUpdateOpenSearchIDs writes (see internal/ratelimit). MongoRPS is
+	// the ceiling it grows back towards, MongoRPSMin the floor it can back
+	// off to, MongoRPSStep how much it grows per successful operation.
+	MongoRPS     float64
+	MongoRPSMin  float64
+	MongoRPSStep float64
+	MongoBurst   int
 
 	// OpenSearch
 	OpenSearchHosts       []string
@@ -24,9 +32,69 @@ type Config struct {
 	OpenSearchIndex       string
 	OpenSearchVerifyCerts bool
 
+	// OpenSearch availability tracking (see opensearch.Client's background
+	// health monitor and circuit breaker): OpenSearchHealthCheckInterval is
+	// how often (in seconds) the monitor pings Cluster Health,
+	// OpenSearchBreakerFailureThreshold how many consecutive BulkIndex
+	// connection failures trip the breaker open, and
+	// OpenSearchBreakerCooldown how many seconds it stays open before
+	// probing again, and OpenSearchBreakerHalfOpenSuccess how many
+	// consecutive half-open probes must succeed before it closes.
+	OpenSearchHealthCheckInterval     int
+	OpenSearchBreakerFailureThreshold int
+	OpenSearchBreakerCooldown         int
+	OpenSearchBreakerHalfOpenSuccess  int
+
 	// Embedding Service
 	EmbeddingServiceURL string
 	EmbeddingTimeout    int
+	EmbedRPS            float64 // Sustained requests/sec allowed to the embedding service
+	EmbedBurst          int     // Token-bucket burst size
+
+	// Embedding provider selection ("tei", "openai", "cohere", "local")
+	EmbeddingProvider string
+	EmbeddingDim      int // Vector dimensionality; must match the OpenSearch knn_vector mapping
+
+	// EmbeddingProviders, if set, is an ordered comma-separated failover
+	// list (e.g. "tei,openai,cohere") that overrides EmbeddingProvider and
+	// wraps the providers behind a FailoverProvider, each with its own
+	// circuit breaker. Empty keeps the single-provider behavior.
+	EmbeddingProviders string
+
+	// Adaptive embedding batch size (AIMD): EmbedBatchSize is the ceiling,
+	// EmbedBatchMinSize the floor it can shrink to under sustained
+	// rate-limit pressure, and EmbedBatchGrowStep how much it grows back
+	// per successful batch.
+	EmbedBatchMinSize  int
+	EmbedBatchGrowStep int
+
+	// Embedding transport for the "tei" provider ("http" default, or "grpc")
+	EmbeddingTransport string
+
+	// OpenAI provider
+	OpenAIAPIKey string
+	OpenAIModel  string
+
+	// Cohere provider
+	CohereAPIKey string
+	CohereModel  string
+
+	// Local (ONNX/GGUF) provider
+	LocalModelPath string
+
+	// Embedding disk cache (keyed by content hash, shared across runs)
+	EmbedCacheEnabled    bool
+	EmbedCacheTTLHours   int
+	EmbedCacheMaxEntries int
+
+	// Embedding service circuit breaker
+	EmbedBreakerFailureThreshold int    // Consecutive failures before tripping open
+	EmbedBreakerCooldown         int    // Seconds to stay open before probing again
+	EmbedBreakerHalfOpenSuccess  int    // Consecutive half-open successes needed to close
+	HealthzAddr                  string // Listen address for the `healthz` command
+
+	// Adaptive request batching
+	EmbedMaxCharsPerRequest int // Proactive char budget per embedding request; 0 disables
 
 	// Batch sizes
 	MongoBatchSize     int
@@ -40,8 +108,69 @@ type Config struct {
 	MaxRetries int
 	RetryDelay int
 
+	// Streaming bulk indexer (see opensearch.BulkIndexer): BulkFlushBytes
+	// and BulkFlushIntervalMs bound how long documents sit buffered before a
+	// worker flushes them, and BulkWorkers is how many flush concurrently.
+	// MaxRetries caps retries of 429/retryable items within a flush.
+	BulkFlushBytes      int
+	BulkFlushIntervalMs int
+	BulkWorkers         int
+
 	// Cache (for two-phase indexing)
-	CacheDir string
+	CacheDir     string
+	CacheBackend string // "file" (default) or "redis"
+
+	// Redis cache backend (used when CacheBackend == "redis")
+	RedisAddr      string
+	RedisPassword  string
+	RedisDB        int
+	RedisKeyPrefix string // Namespaces keys so multiple indexes can share one Redis instance
+
+	// Segmented cache backend (used when CacheBackend == "segmented")
+	CacheSegmentFsyncEvery int // Flush and fsync changed segments after this many AddEntries calls
+	CacheSegmentCount      int // Fixed number of ID-hashed segment buckets; must not change across runs against the same cache dir
+
+	// Change stream (real-time incremental indexing)
+	ChangeStreamCoalesceMs int // How long to coalesce rapid updates to the same _id before embedding/indexing
+
+	// Schema migrations: target version for the mongodb package's built-in
+	// migrations (see internal/mongodb/migrations.go). 0 means "latest".
+	MigrationTargetVersion int
+
+	// Output selects the cli.CLI Emitter: "plain" (Docker-style), "json"
+	// (newline-delimited events for CI/dashboards), or "auto" (default;
+	// picks JSON when stdout isn't a terminal). Overridden by --output.
+	Output string
+
+	// Distributed lease (mongodb.Leaser): lets two indexer replicas run for
+	// availability without double-indexing. Disabled by default since a
+	// single-replica deployment has no need for it.
+	LeaseEnabled    bool
+	LeaseTTLSeconds int
+
+	// Multi-tenancy: when TenantIDs is non-empty, every tenant's documents
+	// live in the same MongoCollection, distinguished by a tenant_id field
+	// (see mongodb.Document), and the CLI processes each tenant ID in turn
+	// instead of running one unscoped pass over the whole collection. Empty
+	// means single-tenant: no tenant_id filtering at all.
+	TenantIDs []string
+	// TenantParallelism caps how many tenants run at once; 0 or 1 means
+	// serial, matching single-tenant behavior exactly.
+	TenantParallelism int
+
+	// Hybrid search (see opensearch.Client.HybridSearch): SearchTopK is the
+	// default result count, SearchRRFK the RRF rank-smoothing constant, and
+	// SearchBM25Weight/SearchKNNWeight the per-branch fusion weights.
+	SearchTopK       int
+	SearchRRFK       int
+	SearchBM25Weight float64
+	SearchKNNWeight  float64
+
+	// SearchBackend selects the search.Backend Phase 2 and the search
+	// command write to and query: "opensearch" (default) for production,
+	// or "bleve" for the embedded, in-process engine used in local
+	// development and CI where a cluster isn't practical.
+	SearchBackend string
 }
 
 // Load reads configuration from environment variables
@@ -55,7 +184,11 @@ func Load() *Config {
 		MongoCollection:   getEnv("MONGODB_COLLECTION", "researchmetadatascopuses"),
 		MongoMaxPoolSize:  getEnvInt("MONGO_MAX_POOL_SIZE", 20), // Increased for higher concurrency
 		MongoFetchDelayMs: getEnvInt("MONGO_FETCH_DELAY_MS", 5), // Small delay between cursor reads
-		MongoBulkDelayMs:  getEnvInt("MONGO_BULK_DELAY_MS", 50), // Delay between bulk writes
+
+		MongoRPS:     getEnvFloat("MONGO_RPS", 20),     // Sustained rate into MongoDB reads/writes
+		MongoRPSMin:  getEnvFloat("MONGO_RPS_MIN", 2),  // Floor under sustained throttling
+		MongoRPSStep: getEnvFloat("MONGO_RPS_STEP", 2), // Growth per successful operation
+		MongoBurst:   getEnvInt("MONGO_BURST", 20),     // Allow short bursts above the sustained rate
 
 		// OpenSearch
 		OpenSearchHosts:       strings.Split(getEnv("OPENSEARCH_HOSTS", "https://localhost:9200"), ","),
@@ -64,9 +197,45 @@ func Load() *Config {
 		OpenSearchIndex:       getEnv("OPENSEARCH_INDEX", "research_documents"),
 		OpenSearchVerifyCerts: getEnv("OPENSEARCH_VERIFY_CERTS", "false") == "true",
 
+		OpenSearchHealthCheckInterval:     getEnvInt("OPENSEARCH_HEALTH_CHECK_INTERVAL_SECONDS", 10),
+		OpenSearchBreakerFailureThreshold: getEnvInt("OPENSEARCH_BREAKER_FAILURE_THRESHOLD", 3),
+		OpenSearchBreakerCooldown:         getEnvInt("OPENSEARCH_BREAKER_COOLDOWN_SECONDS", 30),
+		OpenSearchBreakerHalfOpenSuccess:  getEnvInt("OPENSEARCH_BREAKER_HALF_OPEN_SUCCESS", 2),
+
 		// Embedding
 		EmbeddingServiceURL: getEnv("EMBEDDING_SERVICE_URL", "http://localhost:8001"),
 		EmbeddingTimeout:    getEnvInt("EMBEDDING_TIMEOUT", 60), // Increased from 30s for slower services
+		EmbedRPS:            getEnvFloat("EMBED_RPS", 10),       // Sustained rate into the embedding service
+		EmbedBurst:          getEnvInt("EMBED_BURST", 20),       // Allow short bursts above the sustained rate
+
+		EmbeddingProvider: getEnv("EMBEDDING_PROVIDER", "tei"),
+		EmbeddingDim:      getEnvInt("EMBEDDING_DIM", 768), // SPECTER2's native dimensionality
+
+		EmbeddingProviders: getEnv("EMBEDDING_PROVIDERS", ""),
+
+		EmbedBatchMinSize:  getEnvInt("EMBED_BATCH_MIN_SIZE", 8),
+		EmbedBatchGrowStep: getEnvInt("EMBED_BATCH_GROW_STEP", 8),
+
+		EmbeddingTransport: getEnv("EMBEDDING_TRANSPORT", "http"), // "http" (default) or "grpc"
+
+		OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
+		OpenAIModel:  getEnv("OPENAI_EMBEDDING_MODEL", "text-embedding-3-small"),
+
+		CohereAPIKey: getEnv("COHERE_API_KEY", ""),
+		CohereModel:  getEnv("COHERE_EMBEDDING_MODEL", "embed-english-v3.0"),
+
+		LocalModelPath: getEnv("LOCAL_EMBEDDING_MODEL_PATH", ""),
+
+		EmbedCacheEnabled:    getEnv("EMBED_CACHE_ENABLED", "true") == "true",
+		EmbedCacheTTLHours:   getEnvInt("EMBED_CACHE_TTL_HOURS", 24*30), // 30 days
+		EmbedCacheMaxEntries: getEnvInt("EMBED_CACHE_MAX_ENTRIES", 2_000_000),
+
+		EmbedBreakerFailureThreshold: getEnvInt("EMBED_BREAKER_FAILURE_THRESHOLD", 5),
+		EmbedBreakerCooldown:         getEnvInt("EMBED_BREAKER_COOLDOWN_SECONDS", 30),
+		EmbedBreakerHalfOpenSuccess:  getEnvInt("EMBED_BREAKER_HALF_OPEN_SUCCESS", 2),
+		HealthzAddr:                  getEnv("HEALTHZ_ADDR", ":8090"),
+
+		EmbedMaxCharsPerRequest: getEnvInt("EMBED_MAX_CHARS_PER_REQUEST", 200_000),
 
 		// Batch sizes - smaller for free tier
 		MongoBatchSize:     getEnvInt("MONGO_BATCH_SIZE", 100),     // Increased from 50
@@ -80,8 +249,46 @@ func Load() *Config {
 		MaxRetries: getEnvInt("MAX_RETRIES", 3),
 		RetryDelay: getEnvInt("RETRY_DELAY", 5),
 
+		// Streaming bulk indexer
+		BulkFlushBytes:      getEnvInt("BULK_FLUSH_BYTES", 5*1024*1024),
+		BulkFlushIntervalMs: getEnvInt("BULK_FLUSH_INTERVAL_MS", 5000),
+		BulkWorkers:         getEnvInt("BULK_WORKERS", 4),
+
 		// Cache
-		CacheDir: getEnv("CACHE_DIR", ".cache"),
+		CacheDir:     getEnv("CACHE_DIR", ".cache"),
+		CacheBackend: getEnv("CACHE_BACKEND", "file"),
+
+		RedisAddr:      getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:  getEnv("REDIS_PASSWORD", ""),
+		RedisDB:        getEnvInt("REDIS_DB", 0),
+		RedisKeyPrefix: getEnv("REDIS_KEY_PREFIX", "indexer"),
+
+		CacheSegmentFsyncEvery: getEnvInt("CACHE_SEGMENT_FSYNC_EVERY", 500),
+		CacheSegmentCount:      getEnvInt("CACHE_SEGMENT_COUNT", 256),
+
+		// Change stream
+		ChangeStreamCoalesceMs: getEnvInt("CHANGE_STREAM_COALESCE_MS", 2000),
+
+		// Schema migrations
+		MigrationTargetVersion: getEnvInt("MIGRATION_TARGET_VERSION", 0),
+
+		// Output
+		Output: getEnv("OUTPUT_FORMAT", "auto"),
+
+		// Distributed lease
+		LeaseEnabled:    getEnv("LEASE_ENABLED", "false") == "true",
+		LeaseTTLSeconds: getEnvInt("LEASE_TTL_SECONDS", 30),
+
+		// Multi-tenancy
+		TenantIDs:         getEnvList("TENANT_IDS"),
+		TenantParallelism: getEnvInt("TENANT_PARALLELISM", 1),
+
+		// Hybrid search
+		SearchTopK:       getEnvInt("SEARCH_TOP_K", 10),
+		SearchRRFK:       getEnvInt("SEARCH_RRF_K", 60),
+		SearchBM25Weight: getEnvFloat("SEARCH_BM25_WEIGHT", 1.0),
+		SearchKNNWeight:  getEnvFloat("SEARCH_KNN_WEIGHT", 1.0),
+		SearchBackend:    getEnv("SEARCH_BACKEND", "opensearch"),
 	}
 }
 
@@ -100,3 +307,29 @@ func getEnvInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+// getEnvList reads a comma-separated env var into a slice, or nil if unset
+// or empty (strings.Split on "" would otherwise yield []string{""}).
+func getEnvList(key string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return nil
+	}
+	parts := strings.Split(val, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	}
+	return defaultVal
+}
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// envKeys lists every Config field's env var name alongside the viper key
+// it's bound under. The viper key is always the lowercased env var name, so
+// viper's AutomaticEnv (which upper-cases a looked-up key before checking
+// the environment) resolves it to the same variable Load already reads -
+// existing deployments' env vars keep working unchanged under the new
+// flags > env > config.yaml > defaults precedence chain.
+var envKeys = []string{
+	"MONGODB_URI", "MONGODB_COLLECTION", "MONGO_MAX_POOL_SIZE", "MONGO_FETCH_DELAY_MS",
+	"MONGO_RPS", "MONGO_RPS_MIN", "MONGO_RPS_STEP", "MONGO_BURST",
+	"OPENSEARCH_HOSTS", "OPENSEARCH_USER", "OPENSEARCH_PASSWORD", "OPENSEARCH_INDEX", "OPENSEARCH_VERIFY_CERTS",
+	"OPENSEARCH_HEALTH_CHECK_INTERVAL_SECONDS", "OPENSEARCH_BREAKER_FAILURE_THRESHOLD",
+	"OPENSEARCH_BREAKER_COOLDOWN_SECONDS", "OPENSEARCH_BREAKER_HALF_OPEN_SUCCESS",
+	"EMBEDDING_SERVICE_URL", "EMBEDDING_TIMEOUT", "EMBED_RPS", "EMBED_BURST",
+	"EMBEDDING_PROVIDER", "EMBEDDING_DIM", "EMBEDDING_PROVIDERS",
+	"EMBED_BATCH_MIN_SIZE", "EMBED_BATCH_GROW_STEP", "EMBEDDING_TRANSPORT",
+	"OPENAI_API_KEY", "OPENAI_EMBEDDING_MODEL",
+	"COHERE_API_KEY", "COHERE_EMBEDDING_MODEL",
+	"LOCAL_EMBEDDING_MODEL_PATH",
+	"EMBED_CACHE_ENABLED", "EMBED_CACHE_TTL_HOURS", "EMBED_CACHE_MAX_ENTRIES",
+	"EMBED_BREAKER_FAILURE_THRESHOLD", "EMBED_BREAKER_COOLDOWN_SECONDS", "EMBED_BREAKER_HALF_OPEN_SUCCESS",
+	"HEALTHZ_ADDR", "EMBED_MAX_CHARS_PER_REQUEST",
+	"MONGO_BATCH_SIZE", "EMBED_BATCH_SIZE", "OPENSEARCH_BULK_SIZE",
+	"NUM_WORKERS", "MAX_RETRIES", "RETRY_DELAY",
+	"BULK_FLUSH_BYTES", "BULK_FLUSH_INTERVAL_MS", "BULK_WORKERS",
+	"CACHE_DIR", "CACHE_BACKEND",
+	"REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB", "REDIS_KEY_PREFIX",
+	"CACHE_SEGMENT_FSYNC_EVERY", "CACHE_SEGMENT_COUNT",
+	"CHANGE_STREAM_COALESCE_MS",
+	"MIGRATION_TARGET_VERSION",
+	"OUTPUT_FORMAT",
+	"LEASE_ENABLED", "LEASE_TTL_SECONDS",
+	"TENANT_IDS", "TENANT_PARALLELISM",
+	"SEARCH_TOP_K", "SEARCH_RRF_K", "SEARCH_BM25_WEIGHT", "SEARCH_KNN_WEIGHT",
+	"SEARCH_BACKEND",
+}
+
+// viperKey lowercases an env var name into its matching viper/YAML key, e.g.
+// "MONGODB_URI" -> "mongodb_uri".
+func viperKey(envVar string) string {
+	return strings.ToLower(envVar)
+}
+
+// LoadWithViper builds a Config the same way Load does, but through v so
+// that flags bound via BindFlags (highest precedence), a YAML file at
+// configPath, and environment variables all participate in one resolution
+// chain ahead of the same defaults Load uses. configPath may be empty, in
+// which case no config file is read and a missing file is not an error.
+func LoadWithViper(v *viper.Viper, configPath string) (*Config, error) {
+	setDefaults(v)
+
+	for _, envVar := range envKeys {
+		if err := v.BindEnv(viperKey(envVar), envVar); err != nil {
+			return nil, fmt.Errorf("bind env %s: %w", envVar, err)
+		}
+	}
+	v.AutomaticEnv()
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file %s: %w", configPath, err)
+		}
+	}
+
+	return &Config{
+		MongoURI:          v.GetString("mongodb_uri"),
+		MongoCollection:   v.GetString("mongodb_collection"),
+		MongoMaxPoolSize:  v.GetInt("mongo_max_pool_size"),
+		MongoFetchDelayMs: v.GetInt("mongo_fetch_delay_ms"),
+
+		MongoRPS:     v.GetFloat64("mongo_rps"),
+		MongoRPSMin:  v.GetFloat64("mongo_rps_min"),
+		MongoRPSStep: v.GetFloat64("mongo_rps_step"),
+		MongoBurst:   v.GetInt("mongo_burst"),
+
+		OpenSearchHosts:       viperList(v, "opensearch_hosts"),
+		OpenSearchUser:        v.GetString("opensearch_user"),
+		OpenSearchPassword:    v.GetString("opensearch_password"),
+		OpenSearchIndex:       v.GetString("opensearch_index"),
+		OpenSearchVerifyCerts: v.GetBool("opensearch_verify_certs"),
+
+		OpenSearchHealthCheckInterval:     v.GetInt("opensearch_health_check_interval_seconds"),
+		OpenSearchBreakerFailureThreshold: v.GetInt("opensearch_breaker_failure_threshold"),
+		OpenSearchBreakerCooldown:         v.GetInt("opensearch_breaker_cooldown_seconds"),
+		OpenSearchBreakerHalfOpenSuccess:  v.GetInt("opensearch_breaker_half_open_success"),
+
+		EmbeddingServiceURL: v.GetString("embedding_service_url"),
+		EmbeddingTimeout:    v.GetInt("embedding_timeout"),
+		EmbedRPS:            v.GetFloat64("embed_rps"),
+		EmbedBurst:          v.GetInt("embed_burst"),
+
+		EmbeddingProvider: v.GetString("embedding_provider"),
+		EmbeddingDim:      v.GetInt("embedding_dim"),
+
+		EmbeddingProviders: v.GetString("embedding_providers"),
+
+		EmbedBatchMinSize:  v.GetInt("embed_batch_min_size"),
+		EmbedBatchGrowStep: v.GetInt("embed_batch_grow_step"),
+
+		EmbeddingTransport: v.GetString("embedding_transport"),
+
+		OpenAIAPIKey: v.GetString("openai_api_key"),
+		OpenAIModel:  v.GetString("openai_embedding_model"),
+
+		CohereAPIKey: v.GetString("cohere_api_key"),
+		CohereModel:  v.GetString("cohere_embedding_model"),
+
+		LocalModelPath: v.GetString("local_embedding_model_path"),
+
+		EmbedCacheEnabled:    v.GetBool("embed_cache_enabled"),
+		EmbedCacheTTLHours:   v.GetInt("embed_cache_ttl_hours"),
+		EmbedCacheMaxEntries: v.GetInt("embed_cache_max_entries"),
+
+		EmbedBreakerFailureThreshold: v.GetInt("embed_breaker_failure_threshold"),
+		EmbedBreakerCooldown:         v.GetInt("embed_breaker_cooldown_seconds"),
+		EmbedBreakerHalfOpenSuccess:  v.GetInt("embed_breaker_half_open_success"),
+		HealthzAddr:                  v.GetString("healthz_addr"),
+
+		EmbedMaxCharsPerRequest: v.GetInt("embed_max_chars_per_request"),
+
+		MongoBatchSize:     v.GetInt("mongo_batch_size"),
+		EmbedBatchSize:     v.GetInt("embed_batch_size"),
+		OpenSearchBulkSize: v.GetInt("opensearch_bulk_size"),
+
+		NumWorkers: v.GetInt("num_workers"),
+
+		MaxRetries: v.GetInt("max_retries"),
+		RetryDelay: v.GetInt("retry_delay"),
+
+		BulkFlushBytes:      v.GetInt("bulk_flush_bytes"),
+		BulkFlushIntervalMs: v.GetInt("bulk_flush_interval_ms"),
+		BulkWorkers:         v.GetInt("bulk_workers"),
+
+		CacheDir:     v.GetString("cache_dir"),
+		CacheBackend: v.GetString("cache_backend"),
+
+		RedisAddr:      v.GetString("redis_addr"),
+		RedisPassword:  v.GetString("redis_password"),
+		RedisDB:        v.GetInt("redis_db"),
+		RedisKeyPrefix: v.GetString("redis_key_prefix"),
+
+		CacheSegmentFsyncEvery: v.GetInt("cache_segment_fsync_every"),
+		CacheSegmentCount:      v.GetInt("cache_segment_count"),
+
+		ChangeStreamCoalesceMs: v.GetInt("change_stream_coalesce_ms"),
+
+		MigrationTargetVersion: v.GetInt("migration_target_version"),
+
+		Output: v.GetString("output_format"),
+
+		LeaseEnabled:    v.GetBool("lease_enabled"),
+		LeaseTTLSeconds: v.GetInt("lease_ttl_seconds"),
+
+		TenantIDs:         viperList(v, "tenant_ids"),
+		TenantParallelism: v.GetInt("tenant_parallelism"),
+
+		SearchTopK:       v.GetInt("search_top_k"),
+		SearchRRFK:       v.GetInt("search_rrf_k"),
+		SearchBM25Weight: v.GetFloat64("search_bm25_weight"),
+		SearchKNNWeight:  v.GetFloat64("search_knn_weight"),
+		SearchBackend:    v.GetString("search_backend"),
+	}, nil
+}
+
+// setDefaults mirrors Load's defaults exactly, so a fresh viper.Viper with
+// no flags bound, no config file, and no env vars set produces the same
+// Config Load() would.
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("mongodb_uri", "mongodb://localhost:27017/research_db")
+	v.SetDefault("mongodb_collection", "researchmetadatascopuses")
+	v.SetDefault("mongo_max_pool_size", 20)
+	v.SetDefault("mongo_fetch_delay_ms", 5)
+
+	v.SetDefault("mongo_rps", 20)
+	v.SetDefault("mongo_rps_min", 2)
+	v.SetDefault("mongo_rps_step", 2)
+	v.SetDefault("mongo_burst", 20)
+
+	v.SetDefault("opensearch_hosts", "https://localhost:9200")
+	v.SetDefault("opensearch_user", "admin")
+	v.SetDefault("opensearch_password", "admin")
+	v.SetDefault("opensearch_index", "research_documents")
+	v.SetDefault("opensearch_verify_certs", false)
+
+	v.SetDefault("opensearch_health_check_interval_seconds", 10)
+	v.SetDefault("opensearch_breaker_failure_threshold", 3)
+	v.SetDefault("opensearch_breaker_cooldown_seconds", 30)
+	v.SetDefault("opensearch_breaker_half_open_success", 2)
+
+	v.SetDefault("embedding_service_url", "http://localhost:8001")
+	v.SetDefault("embedding_timeout", 60)
+	v.SetDefault("embed_rps", 10)
+	v.SetDefault("embed_burst", 20)
+
+	v.SetDefault("embedding_provider", "tei")
+	v.SetDefault("embedding_dim", 768)
+	v.SetDefault("embedding_providers", "")
+
+	v.SetDefault("embed_batch_min_size", 8)
+	v.SetDefault("embed_batch_grow_step", 8)
+
+	v.SetDefault("embedding_transport", "http")
+
+	v.SetDefault("openai_api_key", "")
+	v.SetDefault("openai_embedding_model", "text-embedding-3-small")
+
+	v.SetDefault("cohere_api_key", "")
+	v.SetDefault("cohere_embedding_model", "embed-english-v3.0")
+
+	v.SetDefault("local_embedding_model_path", "")
+
+	v.SetDefault("embed_cache_enabled", true)
+	v.SetDefault("embed_cache_ttl_hours", 24*30)
+	v.SetDefault("embed_cache_max_entries", 2_000_000)
+
+	v.SetDefault("embed_breaker_failure_threshold", 5)
+	v.SetDefault("embed_breaker_cooldown_seconds", 30)
+	v.SetDefault("embed_breaker_half_open_success", 2)
+	v.SetDefault("healthz_addr", ":8090")
+
+	v.SetDefault("embed_max_chars_per_request", 200_000)
+
+	v.SetDefault("mongo_batch_size", 100)
+	v.SetDefault("embed_batch_size", 128)
+	v.SetDefault("opensearch_bulk_size", 100)
+
+	v.SetDefault("num_workers", 8)
+
+	v.SetDefault("max_retries", 3)
+	v.SetDefault("retry_delay", 5)
+
+	v.SetDefault("bulk_flush_bytes", 5*1024*1024)
+	v.SetDefault("bulk_flush_interval_ms", 5000)
+	v.SetDefault("bulk_workers", 4)
+
+	v.SetDefault("cache_dir", ".cache")
+	v.SetDefault("cache_backend", "file")
+
+	v.SetDefault("redis_addr", "localhost:6379")
+	v.SetDefault("redis_password", "")
+	v.SetDefault("redis_db", 0)
+	v.SetDefault("redis_key_prefix", "indexer")
+
+	v.SetDefault("cache_segment_fsync_every", 500)
+	v.SetDefault("cache_segment_count", 256)
+
+	v.SetDefault("change_stream_coalesce_ms", 2000)
+
+	v.SetDefault("migration_target_version", 0)
+
+	v.SetDefault("output_format", "auto")
+
+	v.SetDefault("lease_enabled", false)
+	v.SetDefault("lease_ttl_seconds", 30)
+
+	v.SetDefault("tenant_ids", "")
+	v.SetDefault("tenant_parallelism", 1)
+
+	v.SetDefault("search_top_k", 10)
+	v.SetDefault("search_rrf_k", 60)
+	v.SetDefault("search_bm25_weight", 1.0)
+	v.SetDefault("search_knn_weight", 1.0)
+	v.SetDefault("search_backend", "opensearch")
+}
+
+// viperList splits a comma-separated viper string value into a slice,
+// trimming whitespace and dropping empty elements - mirrors getEnvList, but
+// reads through v so flags/config.yaml/env all feed the same list fields.
+func viperList(v *viper.Viper, key string) []string {
+	val := v.GetString(key)
+	if val == "" {
+		return nil
+	}
+	parts := strings.Split(val, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package embedding
+
+import (
+	"sync"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// BatchSizer tracks a dynamic embedding sub-batch size that shrinks
+// multiplicatively under rate-limit pressure and grows additively back
+// towards its ceiling otherwise (AIMD), so Phase 1's workers back off a
+// struggling embedding service instead of hammering it at a fixed batch
+// size.
+type BatchSizer struct {
+	mu sync.Mutex
+
+	size int
+	min  int
+	max  int
+	step int
+}
+
+// NewBatchSizer builds a BatchSizer starting at cfg.EmbedBatchSize (the
+// ceiling), floored at cfg.EmbedBatchMinSize and growing cfg.EmbedBatchGrowStep
+// at a time.
+func NewBatchSizer(cfg *config.Config) *BatchSizer {
+	max := cfg.EmbedBatchSize
+	if max <= 0 {
+		max = 128
+	}
+	min := cfg.EmbedBatchMinSize
+	if min <= 0 {
+		min = 8
+	}
+	if min > max {
+		min = max
+	}
+	step := cfg.EmbedBatchGrowStep
+	if step <= 0 {
+		step = 8
+	}
+
+	return &BatchSizer{size: max, min: min, max: max, step: step}
+}
+
+// Size returns the current batch size to use.
+func (b *BatchSizer) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// Shrink halves the batch size (floored at min), called after a batch fails
+// with a rate-limit-shaped error.
+func (b *BatchSizer) Shrink() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.size /= 2
+	if b.size < b.min {
+		b.size = b.min
+	}
+}
+
+// Grow adds one step back towards max, called after a batch succeeds.
+func (b *BatchSizer) Grow() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.size += b.step
+	if b.size > b.max {
+		b.size = b.max
+	}
+}
+
+// IsRateLimited reports whether err looks like the kind of rate-limit
+// pressure the AIMD sizer should react to by shrinking: the circuit
+// breaker tripping, an HTTP 429, or a 5xx suggesting the service is
+// overloaded.
+func IsRateLimited(err error) bool {
+	return isFailoverWorthy(err)
+}
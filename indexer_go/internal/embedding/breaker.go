@@ -0,0 +1,151 @@
+package embedding
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// ErrCircuitOpen is returned by GetEmbeddings when the circuit breaker is
+// open, so callers can distinguish "the service is known to be down, don't
+// bother retrying right now" from an ordinary transient error and decide to
+// park the batch on disk instead of burning retries against it.
+var ErrCircuitOpen = errors.New("embedding: circuit breaker open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker trips after a run of consecutive failures and fails fast
+// while open, periodically allowing a single probe request through
+// (half-open) to decide whether to close again.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state            breakerState
+	consecutiveFails int
+	consecutiveOK    int
+	openedAt         time.Time
+	failureThreshold int
+	cooldown         time.Duration
+	halfOpenOKNeeded int
+}
+
+func newCircuitBreaker(cfg *config.Config) *circuitBreaker {
+	threshold := cfg.EmbedBreakerFailureThreshold
+	if threshold <= 0 {
+		threshold = 5
+	}
+	cooldown := cfg.EmbedBreakerCooldown
+	if cooldown <= 0 {
+		cooldown = 30
+	}
+	halfOpenOK := cfg.EmbedBreakerHalfOpenSuccess
+	if halfOpenOK <= 0 {
+		halfOpenOK = 2
+	}
+
+	return &circuitBreaker{
+		state:            breakerClosed,
+		failureThreshold: threshold,
+		cooldown:         time.Duration(cooldown) * time.Second,
+		halfOpenOKNeeded: halfOpenOK,
+	}
+}
+
+// Allow reports whether a request should proceed, transitioning open ->
+// half-open once the cooldown has elapsed.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) >= b.cooldown {
+			b.state = breakerHalfOpen
+			b.consecutiveOK = 0
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess registers a successful call and closes the breaker if enough
+// consecutive half-open probes have succeeded.
+func (b *circuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFails = 0
+
+	switch b.state {
+	case breakerHalfOpen:
+		b.consecutiveOK++
+		if b.consecutiveOK >= b.halfOpenOKNeeded {
+			b.state = breakerClosed
+		}
+	case breakerOpen:
+		// Shouldn't happen since Allow() gates requests, but stay defensive.
+		b.state = breakerClosed
+	}
+}
+
+// RecordFailure registers a failed call, tripping the breaker open once the
+// failure threshold is hit (or immediately, if a half-open probe failed).
+func (b *circuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerHalfOpen:
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// String renders the breaker state the way /healthz reports it.
+func (b *circuitBreaker) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// HealthHandler serves the embedding circuit breaker's current state as JSON,
+// so orchestrators (k8s liveness/readiness probes) can see it without
+// parsing logs.
+func (c *TEIProvider) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := c.breaker.String()
+		w.Header().Set("Content-Type", "application/json")
+		if state == "open" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write([]byte(`{"embedding_circuit_breaker":"` + state + `"}`))
+	})
+}
@@ -0,0 +1,106 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// cohereDims maps known Cohere embedding models to their output dimension.
+var cohereDims = map[string]int{
+	"embed-english-v3.0":       1024,
+	"embed-multilingual-v3.0":  1024,
+	"embed-english-light-v3.0": 384,
+}
+
+// CohereProvider embeds text via Cohere's /v1/embed endpoint.
+type CohereProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	model      string
+	dim        int
+}
+
+// NewCohereProvider creates a Provider backed by Cohere's embeddings API.
+func NewCohereProvider(cfg *config.Config) (*CohereProvider, error) {
+	if cfg.CohereAPIKey == "" {
+		return nil, fmt.Errorf("COHERE_API_KEY is required for the cohere embedding provider")
+	}
+
+	dim := cfg.EmbeddingDim
+	if known, ok := cohereDims[cfg.CohereModel]; ok {
+		dim = known
+	}
+
+	return &CohereProvider{
+		httpClient: &http.Client{Timeout: time.Duration(cfg.EmbeddingTimeout) * time.Second},
+		apiKey:     cfg.CohereAPIKey,
+		model:      cfg.CohereModel,
+		dim:        dim,
+	}, nil
+}
+
+type cohereEmbedRequest struct {
+	Model     string   `json:"model"`
+	Texts     []string `json:"texts"`
+	InputType string   `json:"input_type"`
+}
+
+type cohereEmbedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// GetEmbeddings implements Provider.
+func (p *CohereProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	reqBody, err := json.Marshal(cohereEmbedRequest{Model: p.model, Texts: texts, InputType: "search_document"})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cohere.com/v1/embed", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result cohereEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return result.Embeddings, nil
+}
+
+// Dim implements Provider.
+func (p *CohereProvider) Dim() int { return p.dim }
+
+// Name implements Provider.
+func (p *CohereProvider) Name() string { return "cohere" }
+
+// BuildText implements Provider. Cohere has no SPECTER2-style `[SEP]`
+// convention, so title and abstract are joined plainly.
+func (p *CohereProvider) BuildText(title, abstract string) string {
+	return PlainTextBuilder(title, abstract)
+}
@@ -0,0 +1,282 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+var cacheBucket = []byte("embeddings")
+
+// cacheEntry is what actually gets stored in bbolt for each content hash.
+type cacheEntry struct {
+	Embedding  []float32
+	CreatedAt  time.Time
+	LastAccess time.Time
+}
+
+// CachingProvider wraps a Provider with a persistent on-disk cache keyed by a
+// hash of the exact text sent for embedding, namespaced by provider+dim so
+// switching models can never return a stale vector from a different model.
+// This turns re-indexing runs where titles/abstracts are unchanged into a
+// cache lookup instead of a round trip to the embedding service.
+type CachingProvider struct {
+	Provider
+	db         *bolt.DB
+	namespace  string
+	ttl        time.Duration
+	maxEntries int
+}
+
+// NewCachingProvider wraps provider with an on-disk cache rooted at
+// cfg.CacheDir. If cfg.EmbedCacheEnabled is false, provider is returned
+// unwrapped.
+func NewCachingProvider(cfg *config.Config, provider Provider) (Provider, error) {
+	if !cfg.EmbedCacheEnabled {
+		return provider, nil
+	}
+
+	dbPath := filepath.Join(cfg.CacheDir, "embed_cache.bolt")
+	db, err := bolt.Open(dbPath, 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open embedding cache %s: %w", dbPath, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(cacheBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init cache bucket: %w", err)
+	}
+
+	ttl := time.Duration(cfg.EmbedCacheTTLHours) * time.Hour
+
+	return &CachingProvider{
+		Provider:   provider,
+		db:         db,
+		namespace:  fmt.Sprintf("%s:%d", provider.Name(), provider.Dim()),
+		ttl:        ttl,
+		maxEntries: cfg.EmbedCacheMaxEntries,
+	}, nil
+}
+
+// Close releases the underlying bbolt database handle.
+func (c *CachingProvider) Close() error {
+	return c.db.Close()
+}
+
+// healthyProvider is implemented by providers that expose a /healthz style
+// handler, currently just TEIProvider's circuit breaker state.
+type healthyProvider interface {
+	HealthHandler() http.Handler
+}
+
+// HealthHandler forwards to the wrapped provider's health handler if it has
+// one, so wrapping a provider in the cache doesn't hide its breaker state
+// from orchestration.
+func (c *CachingProvider) HealthHandler() http.Handler {
+	if hc, ok := c.Provider.(healthyProvider); ok {
+		return hc.HealthHandler()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"embedding_circuit_breaker":"n/a"}`))
+	})
+}
+
+// providerHealthReporter is implemented by providers that can report a
+// per-backend health summary, currently just FailoverProvider.
+type providerHealthReporter interface {
+	ProviderHealth() map[string]string
+}
+
+// ProviderHealth forwards to the wrapped provider's health summary, if it
+// has one, so wrapping in the cache doesn't hide per-backend status.
+func (c *CachingProvider) ProviderHealth() map[string]string {
+	if hr, ok := c.Provider.(providerHealthReporter); ok {
+		return hr.ProviderHealth()
+	}
+	return nil
+}
+
+// GetEmbeddings partitions texts into cache hits and misses, only round-trips
+// the misses to the wrapped Provider, then merges results back in the
+// original order.
+func (c *CachingProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	keys := make([][]byte, len(texts))
+	results := make([][]float32, len(texts))
+	missIdx := make([]int, 0, len(texts))
+	missTexts := make([]string, 0, len(texts))
+
+	now := time.Now()
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(cacheBucket)
+		for i, text := range texts {
+			key := c.cacheKey(text)
+			keys[i] = key
+
+			raw := b.Get(key)
+			if raw == nil {
+				missIdx = append(missIdx, i)
+				missTexts = append(missTexts, text)
+				continue
+			}
+
+			var entry cacheEntry
+			if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&entry); err != nil {
+				missIdx = append(missIdx, i)
+				missTexts = append(missTexts, text)
+				continue
+			}
+			if c.ttl > 0 && now.Sub(entry.CreatedAt) > c.ttl {
+				missIdx = append(missIdx, i)
+				missTexts = append(missTexts, text)
+				continue
+			}
+
+			results[i] = entry.Embedding
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read embedding cache: %w", err)
+	}
+
+	if len(missTexts) > 0 {
+		fetched, err := c.Provider.GetEmbeddings(ctx, missTexts)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.db.Batch(func(tx *bolt.Tx) error {
+			b := tx.Bucket(cacheBucket)
+			for j, idx := range missIdx {
+				results[idx] = fetched[j]
+				entry := cacheEntry{Embedding: fetched[j], CreatedAt: now, LastAccess: now}
+				var buf bytes.Buffer
+				if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+					return fmt.Errorf("encode cache entry: %w", err)
+				}
+				if err := b.Put(keys[idx], buf.Bytes()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("write embedding cache: %w", err)
+		}
+	} else {
+		// Touch LastAccess on hits so LRU eviction has fresh ordering, best
+		// effort and outside the read transaction above.
+		c.touch(keys)
+	}
+
+	if err := c.evictIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// touch updates LastAccess for cache hits; failures are non-fatal since it
+// only affects eviction ordering, not correctness.
+func (c *CachingProvider) touch(keys [][]byte) {
+	now := time.Now()
+	_ = c.db.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket(cacheBucket)
+		for _, key := range keys {
+			raw := b.Get(key)
+			if raw == nil {
+				continue
+			}
+			var entry cacheEntry
+			if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&entry); err != nil {
+				continue
+			}
+			entry.LastAccess = now
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+				continue
+			}
+			_ = b.Put(key, buf.Bytes())
+		}
+		return nil
+	})
+}
+
+// evictIfNeeded drops the least-recently-used quarter of entries once the
+// cache exceeds maxEntries, keeping the store bounded across long-lived
+// deployments that re-index continuously.
+func (c *CachingProvider) evictIfNeeded() error {
+	if c.maxEntries <= 0 {
+		return nil
+	}
+
+	type keyAccess struct {
+		key        []byte
+		lastAccess time.Time
+	}
+	var all []keyAccess
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(cacheBucket)
+		stats := b.Stats()
+		if stats.KeyN <= c.maxEntries {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var entry cacheEntry
+			if err := gob.NewDecoder(bytes.NewReader(v)).Decode(&entry); err != nil {
+				return nil
+			}
+			all = append(all, keyAccess{key: append([]byte(nil), k...), lastAccess: entry.LastAccess})
+			return nil
+		})
+	})
+	if err != nil || len(all) == 0 {
+		return err
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].lastAccess.Before(all[j].lastAccess) })
+	evictCount := len(all) / 4
+	if evictCount == 0 {
+		return nil
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(cacheBucket)
+		for _, ka := range all[:evictCount] {
+			if err := b.Delete(ka.key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// cacheKey hashes the provider namespace plus the exact embedding text, so a
+// model/dim change can never collide with a different model's vectors.
+func (c *CachingProvider) cacheKey(text string) []byte {
+	h := sha256.New()
+	h.Write([]byte(c.namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return h.Sum(nil)
+}
@@ -0,0 +1,7 @@
+// Package embedpb holds the generated gRPC client/server stubs for
+// embed.proto. Run `go generate ./...` (with protoc, protoc-gen-go, and
+// protoc-gen-go-grpc on PATH) to (re)produce embed.pb.go and
+// embed_grpc.pb.go from embed.proto; neither is hand-edited.
+package embedpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative embed.proto
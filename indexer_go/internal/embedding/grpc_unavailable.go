@@ -0,0 +1,18 @@
+//go:build !grpc
+
+package embedding
+
+import (
+	"fmt"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// NewGRPCProvider is the default-build stand-in for the real constructor in
+// grpc.go, which is excluded unless built with `-tags grpc`. embedpb's
+// generated client stubs aren't checked in yet (see embedpb/generate.go), so
+// the gRPC transport fails fast here with an actionable error instead of
+// pulling embedpb into the default module build.
+func NewGRPCProvider(cfg *config.Config) (Provider, error) {
+	return nil, fmt.Errorf("EMBEDDING_TRANSPORT=grpc requires building with -tags grpc (embedpb stubs are not generated in this build)")
+}
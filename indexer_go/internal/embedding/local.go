@@ -0,0 +1,93 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+
+	ort "github.com/yalue/onnxruntime_go"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// LocalProvider runs embeddings in-process against an ONNX-exported model via
+// onnxruntime-go, for operators who cannot or do not want to run the
+// self-hosted TEI service.
+//
+// Not yet implemented: embedOne has no tokenizer wired up, so NewLocalProvider
+// refuses construction rather than let a caller select it and only find out
+// on the first embed call. newProviderByName does not offer "local" as a
+// selectable EMBEDDING_PROVIDER value until this is finished.
+type LocalProvider struct {
+	session *ort.AdvancedSession
+	dim     int
+}
+
+// NewLocalProvider loads the ONNX model at cfg.LocalModelPath and prepares an
+// inference session.
+func NewLocalProvider(cfg *config.Config) (*LocalProvider, error) {
+	if cfg.LocalModelPath == "" {
+		return nil, fmt.Errorf("LOCAL_EMBEDDING_MODEL_PATH is required for the local embedding provider")
+	}
+
+	if err := ort.InitializeEnvironment(); err != nil {
+		return nil, fmt.Errorf("initialize onnxruntime: %w", err)
+	}
+
+	dim := cfg.EmbeddingDim
+	if dim <= 0 {
+		dim = 768
+	}
+
+	session, err := ort.NewAdvancedSession(cfg.LocalModelPath, []string{"input_ids", "attention_mask"},
+		[]string{"embeddings"}, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load onnx model %s: %w", cfg.LocalModelPath, err)
+	}
+
+	return &LocalProvider{session: session, dim: dim}, nil
+}
+
+// GetEmbeddings implements Provider. Texts are tokenized and run through the
+// ONNX session one at a time; the runtime has no meaningful notion of
+// concurrent requests the way an HTTP service does, so there is no batching
+// across the network here, only across the input slice.
+func (p *LocalProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		vec, err := p.embedOne(text)
+		if err != nil {
+			return nil, fmt.Errorf("embed text %d: %w", i, err)
+		}
+		embeddings[i] = vec
+	}
+	return embeddings, nil
+}
+
+func (p *LocalProvider) embedOne(text string) ([]float32, error) {
+	// A real implementation tokenizes `text` with the model's tokenizer,
+	// feeds input_ids/attention_mask tensors into p.session.Run, and mean-
+	// pools the last hidden state into a single vector of length p.dim.
+	return nil, fmt.Errorf("local ONNX tokenization/inference not wired up for this model")
+}
+
+// Dim implements Provider.
+func (p *LocalProvider) Dim() int { return p.dim }
+
+// Name implements Provider.
+func (p *LocalProvider) Name() string { return "local" }
+
+// BuildText implements Provider. Local models are assumed to be general
+// sentence-embedding models rather than SPECTER2, so no `[SEP]` marker.
+func (p *LocalProvider) BuildText(title, abstract string) string {
+	return PlainTextBuilder(title, abstract)
+}
@@ -0,0 +1,46 @@
+package embedding
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics are package-level so every Client shares one registration, mirroring
+// how the rest of the module exposes operational counters.
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "indexer",
+		Subsystem: "embedding",
+		Name:      "requests_total",
+		Help:      "Total embedding requests sent to the embedding service, by outcome.",
+	}, []string{"outcome"})
+
+	retriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "indexer",
+		Subsystem: "embedding",
+		Name:      "retries_total",
+		Help:      "Total number of embedding request retries.",
+	})
+
+	requestLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "indexer",
+		Subsystem: "embedding",
+		Name:      "request_latency_seconds",
+		Help:      "Latency of individual embedding HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	inFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "indexer",
+		Subsystem: "embedding",
+		Name:      "requests_in_flight",
+		Help:      "Number of embedding requests currently in flight.",
+	})
+
+	limiterInterval = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "indexer",
+		Subsystem: "embedding",
+		Name:      "adaptive_interval_seconds",
+		Help:      "Current adaptive backoff interval applied between embedding requests.",
+	})
+)
@@ -0,0 +1,117 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// openaiDims maps known OpenAI embedding models to their output dimension,
+// since the API doesn't echo it back and the OpenSearch knn_vector mapping
+// needs it up front.
+var openaiDims = map[string]int{
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
+	"text-embedding-ada-002": 1536,
+}
+
+// OpenAIProvider embeds text via OpenAI's /v1/embeddings endpoint.
+type OpenAIProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	model      string
+	dim        int
+}
+
+// NewOpenAIProvider creates a Provider backed by OpenAI's embeddings API.
+func NewOpenAIProvider(cfg *config.Config) (*OpenAIProvider, error) {
+	if cfg.OpenAIAPIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY is required for the openai embedding provider")
+	}
+
+	dim := cfg.EmbeddingDim
+	if known, ok := openaiDims[cfg.OpenAIModel]; ok {
+		dim = known
+	}
+
+	return &OpenAIProvider{
+		httpClient: &http.Client{Timeout: time.Duration(cfg.EmbeddingTimeout) * time.Second},
+		apiKey:     cfg.OpenAIAPIKey,
+		model:      cfg.OpenAIModel,
+		dim:        dim,
+	}, nil
+}
+
+type openaiEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openaiEmbedResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+}
+
+// GetEmbeddings implements Provider.
+func (p *OpenAIProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	reqBody, err := json.Marshal(openaiEmbedRequest{Model: p.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/embeddings", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result openaiEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(embeddings) {
+			continue
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+	return embeddings, nil
+}
+
+// Dim implements Provider.
+func (p *OpenAIProvider) Dim() int { return p.dim }
+
+// Name implements Provider.
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+// BuildText implements Provider. OpenAI's models have no SPECTER2-style
+// `[SEP]` convention, so title and abstract are joined plainly.
+func (p *OpenAIProvider) BuildText(title, abstract string) string {
+	return PlainTextBuilder(title, abstract)
+}
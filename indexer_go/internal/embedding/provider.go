@@ -0,0 +1,120 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// Provider abstracts over embedding backends so the indexer can target the
+// self-hosted TEI service, a hosted API (OpenAI, Cohere), or an in-process
+// local model without the pipeline code caring which one is in use.
+type Provider interface {
+	// GetEmbeddings fetches embeddings for the given texts, in order.
+	GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+	// Dim reports the dimensionality of vectors this provider returns.
+	Dim() int
+	// Name identifies the provider for logging and status output.
+	Name() string
+	// BuildText formats a title/abstract pair into the text actually sent
+	// for embedding, since different models expect different conventions.
+	BuildText(title, abstract string) string
+}
+
+// TextBuilder formats a title/abstract pair into embeddable text.
+type TextBuilder func(title, abstract string) string
+
+// SpecterTextBuilder builds SPECTER2's two-field format: "title [SEP] abstract".
+// This is the convention the self-hosted TEI/SPECTER2 model expects.
+func SpecterTextBuilder(title, abstract string) string {
+	if abstract == "" {
+		return title
+	}
+	return title + " [SEP] " + abstract
+}
+
+// PlainTextBuilder joins title and abstract with a blank line, the
+// convention general-purpose text-embedding models (OpenAI, Cohere) expect
+// rather than SPECTER2's `[SEP]` marker.
+func PlainTextBuilder(title, abstract string) string {
+	if abstract == "" {
+		return title
+	}
+	return title + "\n\n" + abstract
+}
+
+// BuildEmbeddingText creates the text for embedding using SPECTER2 format.
+//
+// Deprecated: use Provider.BuildText so the format matches the provider
+// actually in use. Kept for callers that only ever talk to the TEI service.
+func BuildEmbeddingText(title, abstract string) string {
+	return SpecterTextBuilder(title, abstract)
+}
+
+// NewProvider constructs the embedding Provider selected by
+// cfg.EmbeddingProvider ("tei" by default). If cfg.EmbeddingProviders lists
+// more than one backend, the result is a FailoverProvider that tries them in
+// priority order instead.
+func NewProvider(cfg *config.Config) (Provider, error) {
+	names := providerNames(cfg)
+
+	if len(names) <= 1 {
+		name := cfg.EmbeddingProvider
+		if len(names) == 1 {
+			name = names[0]
+		}
+		return newProviderByName(cfg, name)
+	}
+
+	providers := make([]Provider, 0, len(names))
+	for _, name := range names {
+		p, err := newProviderByName(cfg, name)
+		if err != nil {
+			return nil, fmt.Errorf("embedding provider %q: %w", name, err)
+		}
+		providers = append(providers, p)
+	}
+	return NewFailoverProvider(cfg, providers...), nil
+}
+
+// providerNames returns the ordered list of provider names from
+// cfg.EmbeddingProviders (a comma-separated failover priority list), or nil
+// if it's unset, in which case the caller falls back to cfg.EmbeddingProvider.
+func providerNames(cfg *config.Config) []string {
+	if cfg.EmbeddingProviders == "" {
+		return nil
+	}
+
+	var names []string
+	for _, n := range strings.Split(cfg.EmbeddingProviders, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// newProviderByName constructs a single named backend, the same set
+// NewProvider chooses from for the unwrapped, single-provider case.
+func newProviderByName(cfg *config.Config, name string) (Provider, error) {
+	switch name {
+	case "", "tei":
+		if cfg.EmbeddingTransport == "grpc" {
+			return NewGRPCProvider(cfg)
+		}
+		return NewTEIProvider(cfg), nil
+	case "openai":
+		return NewOpenAIProvider(cfg)
+	case "cohere":
+		return NewCohereProvider(cfg)
+	case "local":
+		// LocalProvider's ONNX tokenization/inference isn't wired up yet
+		// (see embedOne in local.go), so it isn't offered as a selectable
+		// provider until that's finished rather than failing on first use.
+		return nil, fmt.Errorf("embedding provider %q is not implemented yet", name)
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", name)
+	}
+}
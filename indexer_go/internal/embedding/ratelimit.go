@@ -0,0 +1,78 @@
+package embedding
+
+import (
+	"sync"
+	"time"
+)
+
+// adaptiveBackoff tracks recent request latency and error rates with an EWMA
+// and derives an extra spacing interval to insert between requests: it widens
+// when the embedding service looks unhealthy (slow or erroring) and narrows
+// back towards zero as requests keep succeeding quickly.
+type adaptiveBackoff struct {
+	mu sync.Mutex
+
+	latencyEWMA   time.Duration
+	errorRateEWMA float64 // fraction of recent requests that were 5xx/429
+
+	interval time.Duration // current extra delay applied before each request
+
+	// alpha controls how quickly the EWMAs react to new samples.
+	alpha float64
+
+	minInterval time.Duration
+	maxInterval time.Duration
+}
+
+func newAdaptiveBackoff() *adaptiveBackoff {
+	return &adaptiveBackoff{
+		alpha:       0.2,
+		minInterval: 0,
+		maxInterval: 30 * time.Second,
+	}
+}
+
+// Observe records the outcome of a single request and recomputes the interval.
+func (a *adaptiveBackoff) Observe(latency time.Duration, failed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.latencyEWMA == 0 {
+		a.latencyEWMA = latency
+	} else {
+		a.latencyEWMA = time.Duration(a.alpha*float64(latency) + (1-a.alpha)*float64(a.latencyEWMA))
+	}
+
+	sample := 0.0
+	if failed {
+		sample = 1.0
+	}
+	a.errorRateEWMA = a.alpha*sample + (1-a.alpha)*a.errorRateEWMA
+
+	switch {
+	case a.errorRateEWMA > 0.3:
+		// Service is struggling: widen aggressively.
+		a.interval = a.interval*2 + 200*time.Millisecond
+	case a.errorRateEWMA > 0.05:
+		a.interval = a.interval + a.interval/4 + 50*time.Millisecond
+	default:
+		// Healthy: narrow back towards zero.
+		a.interval = a.interval - a.interval/4
+	}
+
+	if a.interval < a.minInterval {
+		a.interval = a.minInterval
+	}
+	if a.interval > a.maxInterval {
+		a.interval = a.maxInterval
+	}
+
+	limiterInterval.Set(a.interval.Seconds())
+}
+
+// Interval returns the current extra delay to insert before the next request.
+func (a *adaptiveBackoff) Interval() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.interval
+}
@@ -0,0 +1,160 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// guardedProvider pairs a Provider with its own circuit breaker, so one
+// backend tripping doesn't poison the failure state of the others.
+type guardedProvider struct {
+	provider Provider
+	breaker  *circuitBreaker
+}
+
+// FailoverProvider tries an ordered list of providers for each call,
+// advancing to the next one when the current provider's breaker is open or
+// it fails with a 429/5xx-shaped error, instead of surfacing that error
+// straight to the caller. All configured providers are expected to share the
+// OpenSearch mapping's dimensionality; Dim and BuildText reflect the primary
+// (first) provider, since that's what's in effect absent a failover.
+type FailoverProvider struct {
+	providers []*guardedProvider
+}
+
+// NewFailoverProvider builds a FailoverProvider that tries providers in the
+// given priority order, each behind its own circuit breaker.
+func NewFailoverProvider(cfg *config.Config, providers ...Provider) *FailoverProvider {
+	guarded := make([]*guardedProvider, len(providers))
+	for i, p := range providers {
+		guarded[i] = &guardedProvider{provider: p, breaker: newCircuitBreaker(cfg)}
+	}
+	return &FailoverProvider{providers: guarded}
+}
+
+// GetEmbeddings tries each provider in priority order. A provider whose
+// breaker is open is skipped without being called; a provider that fails
+// with a non-retryable-looking error (not 429/5xx) returns immediately
+// rather than masking it by trying the rest, since another backend failing
+// over wouldn't fix a bad request.
+func (f *FailoverProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	var lastErr error
+	for _, g := range f.providers {
+		if !g.breaker.Allow() {
+			lastErr = fmt.Errorf("%s: %w", g.provider.Name(), ErrCircuitOpen)
+			continue
+		}
+
+		embeddings, err := g.provider.GetEmbeddings(ctx, texts)
+		if err == nil {
+			g.breaker.RecordSuccess()
+			return embeddings, nil
+		}
+
+		g.breaker.RecordFailure()
+		lastErr = fmt.Errorf("%s: %w", g.provider.Name(), err)
+		if !isFailoverWorthy(err) {
+			return nil, lastErr
+		}
+	}
+
+	if lastErr == nil {
+		return nil, errors.New("embedding: no providers configured")
+	}
+	return nil, fmt.Errorf("all embedding providers exhausted: %w", lastErr)
+}
+
+// isFailoverWorthy reports whether err looks like a failure another provider
+// might not share (rate limiting, a server error) as opposed to one that
+// would fail identically everywhere (a bad request, a config error).
+func isFailoverWorthy(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.statusCode == http.StatusTooManyRequests || reqErr.statusCode >= 500
+	}
+
+	// OpenAI/Cohere don't wrap requestError; fall back to sniffing their
+	// plain "status %d: ..." error text.
+	msg := err.Error()
+	for _, code := range []string{"status 429", "status 500", "status 502", "status 503", "status 504"} {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dim reports the primary provider's dimensionality.
+func (f *FailoverProvider) Dim() int {
+	if len(f.providers) == 0 {
+		return 0
+	}
+	return f.providers[0].provider.Dim()
+}
+
+// Name lists the configured providers in priority order, e.g.
+// "failover(tei,openai)".
+func (f *FailoverProvider) Name() string {
+	names := make([]string, len(f.providers))
+	for i, g := range f.providers {
+		names[i] = g.provider.Name()
+	}
+	return fmt.Sprintf("failover(%s)", strings.Join(names, ","))
+}
+
+// BuildText delegates to the primary provider's text convention.
+func (f *FailoverProvider) BuildText(title, abstract string) string {
+	if len(f.providers) == 0 {
+		return PlainTextBuilder(title, abstract)
+	}
+	return f.providers[0].provider.BuildText(title, abstract)
+}
+
+// ProviderHealth reports each configured provider's circuit breaker state,
+// keyed by provider name, for CacheStatus to surface.
+func (f *FailoverProvider) ProviderHealth() map[string]string {
+	health := make(map[string]string, len(f.providers))
+	for _, g := range f.providers {
+		health["Embedding: "+g.provider.Name()] = g.breaker.String()
+	}
+	return health
+}
+
+// HealthHandler reports unhealthy only once every configured provider's
+// breaker is open, since failover means the service as a whole is still up
+// as long as one backend is available.
+func (f *FailoverProvider) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allOpen := len(f.providers) > 0
+		var body strings.Builder
+		body.WriteString(`{"providers":{`)
+		for i, g := range f.providers {
+			if i > 0 {
+				body.WriteString(",")
+			}
+			state := g.breaker.String()
+			if state != "open" {
+				allOpen = false
+			}
+			fmt.Fprintf(&body, `"%s":"%s"`, g.provider.Name(), state)
+		}
+		body.WriteString("}}")
+
+		w.Header().Set("Content-Type", "application/json")
+		if allOpen {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write([]byte(body.String()))
+	})
+}
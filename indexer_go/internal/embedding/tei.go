@@ -0,0 +1,330 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// EmbedRequest is the request body for the embedding service
+type EmbedRequest struct {
+	Texts []string `json:"texts"`
+}
+
+// EmbedResponse is the response from the embedding service
+type EmbedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// requestError carries the HTTP status and any Retry-After hint so the retry
+// loop can honor server-directed pacing instead of always falling back to the
+// exponential schedule.
+type requestError struct {
+	statusCode int
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+// TEIProvider talks to a self-hosted TEI (text-embeddings-inference) or
+// compatible embedding service over HTTP/JSON.
+type TEIProvider struct {
+	httpClient *http.Client
+	baseURL    string
+	cfg        *config.Config
+	semaphore  chan struct{}    // Limits concurrent requests to embedding service
+	limiter    *rate.Limiter    // Token-bucket limiter bounding sustained request rate
+	backoff    *adaptiveBackoff // EWMA-based adaptive spacing on top of the limiter
+	breaker    *circuitBreaker  // Fails fast while the embedding service looks down
+}
+
+// NewTEIProvider creates a new TEI embedding provider with connection pooling,
+// token-bucket rate limiting, and adaptive backoff.
+func NewTEIProvider(cfg *config.Config) *TEIProvider {
+	// Allow max 2 concurrent embedding requests to avoid overwhelming the service
+	maxConcurrent := 2
+
+	rps := cfg.EmbedRPS
+	if rps <= 0 {
+		rps = 10
+	}
+	burst := cfg.EmbedBurst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &TEIProvider{
+		httpClient: &http.Client{
+			Timeout: time.Duration(cfg.EmbeddingTimeout) * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: maxConcurrent,
+				IdleConnTimeout:     60 * time.Second,
+			},
+		},
+		baseURL:   cfg.EmbeddingServiceURL,
+		cfg:       cfg,
+		semaphore: make(chan struct{}, maxConcurrent),
+		limiter:   rate.NewLimiter(rate.Limit(rps), burst),
+		backoff:   newAdaptiveBackoff(),
+		breaker:   newCircuitBreaker(cfg),
+	}
+}
+
+// GetEmbeddings fetches embeddings for the given texts. It proactively slices
+// texts into sub-batches bounded by EmbedMaxCharsPerRequest before hitting
+// the wire, then for each sub-batch adaptively halves and retries if the
+// service still rejects it as too large, stitching results back in order.
+func (c *TEIProvider) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+
+	batches := splitByCharBudget(texts, c.cfg.EmbedMaxCharsPerRequest)
+	if len(batches) == 1 {
+		return c.embedWithAdaptiveSplit(ctx, texts)
+	}
+
+	results := make([][]float32, 0, len(texts))
+	for _, batch := range batches {
+		embeddings, err := c.embedWithAdaptiveSplit(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, embeddings...)
+	}
+	return results, nil
+}
+
+// embedWithAdaptiveSplit requests texts as a single batch, and on a 413
+// Payload Too Large, a context deadline, or an "input too long" style error
+// from the service, recursively splits the batch in half and retries the
+// halves instead of retrying the same oversized batch.
+func (c *TEIProvider) embedWithAdaptiveSplit(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings, err := c.embedOneBatch(ctx, texts)
+	if err == nil {
+		return embeddings, nil
+	}
+	if len(texts) > 1 && isSplittable(err) {
+		mid := len(texts) / 2
+		left, err := c.embedWithAdaptiveSplit(ctx, texts[:mid])
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.embedWithAdaptiveSplit(ctx, texts[mid:])
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	}
+	return nil, err
+}
+
+// isSplittable reports whether err indicates the batch itself was too large
+// rather than a transient service problem, so splitting it (instead of
+// retrying it unchanged) is the right response.
+func isSplittable(err error) bool {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		if reqErr.statusCode == http.StatusRequestEntityTooLarge {
+			return true
+		}
+		if strings.Contains(strings.ToLower(reqErr.Error()), "too long") ||
+			strings.Contains(strings.ToLower(reqErr.Error()), "too large") {
+			return true
+		}
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// splitByCharBudget groups texts into sub-batches whose total character
+// count stays under budget (0 disables the budget, returning one batch).
+// Any single text longer than the budget still gets its own batch; the wire
+// request may still fail for it, which embedWithAdaptiveSplit handles.
+func splitByCharBudget(texts []string, budget int) [][]string {
+	if budget <= 0 || len(texts) <= 1 {
+		return [][]string{texts}
+	}
+
+	var batches [][]string
+	current := make([]string, 0, len(texts))
+	currentChars := 0
+
+	for _, text := range texts {
+		if len(current) > 0 && currentChars+len(text) > budget {
+			batches = append(batches, current)
+			current = make([]string, 0, len(texts))
+			currentChars = 0
+		}
+		current = append(current, text)
+		currentChars += len(text)
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// embedOneBatch fetches embeddings for a single batch with retry logic and
+// rate limiting. Callers that need oversized-batch splitting should go
+// through embedWithAdaptiveSplit instead of calling this directly.
+func (c *TEIProvider) embedOneBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	// Fail fast while the breaker is open: no semaphore slot consumed, no
+	// sleeping through the retry loop.
+	if !c.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	// Acquire semaphore to limit concurrent requests
+	select {
+	case c.semaphore <- struct{}{}:
+		defer func() { <-c.semaphore }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	var lastErr error
+	for attempt := 0; attempt < c.cfg.MaxRetries; attempt++ {
+		// Wait for a token from the bucket, then add any adaptive spacing
+		// the recent latency/error history calls for.
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		if extra := c.backoff.Interval(); extra > 0 {
+			select {
+			case <-time.After(extra):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		start := time.Now()
+		embeddings, err := c.doRequest(ctx, texts)
+		latency := time.Since(start)
+		requestLatency.Observe(latency.Seconds())
+
+		if err == nil {
+			c.backoff.Observe(latency, false)
+			c.breaker.RecordSuccess()
+			requestsTotal.WithLabelValues("success").Inc()
+			return embeddings, nil
+		}
+		lastErr = err
+		c.backoff.Observe(latency, true)
+		c.breaker.RecordFailure()
+		requestsTotal.WithLabelValues("error").Inc()
+
+		// A too-large batch won't succeed by retrying unchanged; hand it
+		// back immediately so the caller can split it instead.
+		if isSplittable(err) {
+			return nil, err
+		}
+
+		if attempt < c.cfg.MaxRetries-1 {
+			retriesTotal.Inc()
+
+			var reqErr *requestError
+			var wait time.Duration
+			if errors.As(err, &reqErr) && reqErr.statusCode == http.StatusTooManyRequests && reqErr.retryAfter > 0 {
+				// Honor the server's requested pace instead of our own schedule.
+				wait = reqErr.retryAfter
+			} else {
+				// Exponential backoff: 1s, 2s, 4s...
+				wait = time.Duration(1<<attempt) * time.Second
+				if wait > 10*time.Second {
+					wait = 10 * time.Second
+				}
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("failed after %d retries: %w", c.cfg.MaxRetries, lastErr)
+}
+
+func (c *TEIProvider) doRequest(ctx context.Context, texts []string) ([][]float32, error) {
+	reqBody, err := json.Marshal(EmbedRequest{Texts: texts})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embed", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, &requestError{
+			statusCode: resp.StatusCode,
+			retryAfter: retryAfter,
+			err:        fmt.Errorf("status %d: %s", resp.StatusCode, string(body)),
+		}
+	}
+
+	var result EmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return result.Embeddings, nil
+}
+
+// parseRetryAfter parses a Retry-After header value expressed in seconds.
+// HTTP also allows an HTTP-date form, which the embedding service does not
+// send, so that form is intentionally not handled here.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// Dim reports the embedding dimensionality the configured TEI model produces.
+func (c *TEIProvider) Dim() int {
+	if c.cfg.EmbeddingDim > 0 {
+		return c.cfg.EmbeddingDim
+	}
+	return 768
+}
+
+// Name identifies this provider for logging and CacheStatus summaries.
+func (c *TEIProvider) Name() string { return "tei" }
+
+// BuildText formats a title/abstract pair using SPECTER2's two-field
+// convention, which is what the self-hosted TEI model this provider talks to
+// expects.
+func (c *TEIProvider) BuildText(title, abstract string) string {
+	return SpecterTextBuilder(title, abstract)
+}
@@ -0,0 +1,291 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/sudarshan/indexer/internal/mongodb"
+	"github.com/sudarshan/indexer/internal/opensearch"
+)
+
+// resumeTokenFileName is where the change stream's resume token is
+// persisted in the cache dir, so a restart resumes instead of missing
+// events or re-scanning the whole collection.
+const resumeTokenFileName = "changestream_resume_token.bson"
+
+// RunChangeStream watches the source MongoDB collection for changes and
+// reactively embeds + upserts/deletes the affected documents in OpenSearch,
+// so newly ingested or edited papers become searchable within seconds
+// instead of waiting for the next batch run. It runs until ctx is canceled,
+// reconnecting the underlying change stream on transient errors.
+func (idx *Indexer) RunChangeStream(ctx context.Context) error {
+	idx.cli.StartPhase("Change Stream: Real-Time Indexing")
+
+	resumeToken, err := idx.loadResumeToken()
+	if err != nil {
+		idx.cli.Warning(fmt.Sprintf("Could not load resume token: %v (starting from now)", err))
+	}
+
+	// mongoID -> open_search_id, so deletes can still be mapped to a bulk
+	// delete action when the collection doesn't have pre-images enabled and
+	// a delete event therefore arrives without FullDocument.
+	osIDs := &sync.Map{}
+
+	pending := make(map[string]mongodb.ChangeEvent)
+	var pendingMu sync.Mutex
+
+	flush := time.NewTicker(time.Duration(idx.cfg.ChangeStreamCoalesceMs) * time.Millisecond)
+	defer flush.Stop()
+
+	for {
+		events, err := idx.mongoDB.WatchChanges(ctx, resumeToken)
+		if err != nil {
+			return fmt.Errorf("watch changes: %w", err)
+		}
+
+		reconnect := idx.consumeChangeStream(ctx, events, &pending, &pendingMu, flush, &resumeToken, osIDs)
+		if !reconnect {
+			idx.cli.EndPhase()
+			return nil
+		}
+
+		idx.cli.Warning("Change stream closed, reconnecting in 2s...")
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			idx.cli.EndPhase()
+			return nil
+		}
+	}
+}
+
+// consumeChangeStream coalesces incoming events by _id and flushes them to
+// OpenSearch on each tick of flush, until the events channel closes (signals
+// the caller should reconnect) or ctx is canceled (signals a clean stop).
+// It returns whether the caller should reconnect.
+func (idx *Indexer) consumeChangeStream(
+	ctx context.Context,
+	events <-chan mongodb.ChangeEvent,
+	pending *map[string]mongodb.ChangeEvent,
+	pendingMu *sync.Mutex,
+	flush *time.Ticker,
+	resumeToken *bson.Raw,
+	osIDs *sync.Map,
+) bool {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				idx.flushPendingChanges(ctx, pending, pendingMu, osIDs)
+				return true
+			}
+			pendingMu.Lock()
+			(*pending)[ev.DocumentID] = ev
+			pendingMu.Unlock()
+			*resumeToken = ev.ResumeToken
+
+		case <-flush.C:
+			idx.flushPendingChanges(ctx, pending, pendingMu, osIDs)
+			if err := idx.saveResumeToken(*resumeToken); err != nil {
+				idx.cli.Warning(fmt.Sprintf("Could not persist resume token: %v", err))
+			}
+
+		case <-ctx.Done():
+			idx.flushPendingChanges(ctx, pending, pendingMu, osIDs)
+			if err := idx.saveResumeToken(*resumeToken); err != nil {
+				idx.cli.Warning(fmt.Sprintf("Could not persist resume token: %v", err))
+			}
+			return false
+		}
+	}
+}
+
+// flushPendingChanges drains the coalesced pending map and applies each
+// document's latest event: deletes become an OpenSearch bulk delete, and
+// insert/update/replace become an embed + upsert, batched by EmbedBatchSize.
+func (idx *Indexer) flushPendingChanges(
+	ctx context.Context,
+	pending *map[string]mongodb.ChangeEvent,
+	pendingMu *sync.Mutex,
+	osIDs *sync.Map,
+) {
+	pendingMu.Lock()
+	if len(*pending) == 0 {
+		pendingMu.Unlock()
+		return
+	}
+	batch := *pending
+	*pending = make(map[string]mongodb.ChangeEvent)
+	pendingMu.Unlock()
+
+	var deletes []string
+	var upserts []mongodb.Document
+
+	for mongoID, ev := range batch {
+		switch ev.OperationType {
+		case "delete":
+			if osID := resolveOpenSearchID(mongoID, ev, osIDs); osID != "" {
+				deletes = append(deletes, osID)
+			} else {
+				idx.cli.Warning(fmt.Sprintf("No known OpenSearch ID for deleted document %s, skipping", mongoID))
+			}
+		case "insert", "update", "replace":
+			if ev.FullDocument != nil {
+				upserts = append(upserts, *ev.FullDocument)
+			}
+		}
+	}
+
+	if len(deletes) > 0 {
+		if err := idx.openSearch.BulkDelete(ctx, deletes); err != nil {
+			idx.cli.Warning(fmt.Sprintf("Bulk delete failed: %v", err))
+		} else {
+			idx.cli.Success(fmt.Sprintf("Deleted %d documents from OpenSearch", len(deletes)))
+		}
+	}
+
+	// Honor the same worker/batch-size backpressure knobs the batch phases use.
+	workers := max(2, idx.cfg.NumWorkers)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i := 0; i < len(upserts); i += idx.cfg.EmbedBatchSize {
+		end := min(i+idx.cfg.EmbedBatchSize, len(upserts))
+		chunk := upserts[i:end]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(docs []mongodb.Document) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			idx.upsertChangedDocuments(ctx, docs, osIDs)
+		}(chunk)
+	}
+	wg.Wait()
+}
+
+// resolveOpenSearchID finds the OpenSearch _id for a deleted document,
+// preferring the pre-image's stored open_search_id (available only when the
+// collection has change stream pre-images enabled) and falling back to the
+// in-memory map built from insert/update events seen earlier in this run.
+func resolveOpenSearchID(mongoID string, ev mongodb.ChangeEvent, osIDs *sync.Map) string {
+	if ev.FullDocument != nil && ev.FullDocument.OpenSearchID != "" {
+		return ev.FullDocument.OpenSearchID
+	}
+	if v, ok := osIDs.Load(mongoID); ok {
+		return v.(string)
+	}
+	return ""
+}
+
+// upsertChangedDocuments embeds and indexes a batch of changed documents,
+// then records the resulting OpenSearch IDs both in MongoDB and in osIDs so
+// a later delete of the same document can be mapped without a pre-image.
+func (idx *Indexer) upsertChangedDocuments(ctx context.Context, docs []mongodb.Document, osIDs *sync.Map) {
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = idx.embedClient.BuildText(doc.Title, doc.Abstract)
+	}
+
+	embeddings, err := idx.embedClient.GetEmbeddings(ctx, texts)
+	if err != nil {
+		idx.cli.Warning(fmt.Sprintf("Embedding failed for %d changed documents: %v", len(docs), err))
+		return
+	}
+
+	osDocs := make([]opensearch.OSDocument, len(docs))
+	for i, doc := range docs {
+		osAuthors := make([]opensearch.OSAuthor, len(doc.Authors))
+		authorNames := make([]string, len(doc.Authors))
+		allVariants := make([]string, 0)
+
+		for k, a := range doc.Authors {
+			authorNames[k] = a.AuthorName
+			if len(a.AuthorAvailableNames) > 0 {
+				allVariants = append(allVariants, a.AuthorAvailableNames...)
+			}
+
+			position := 0
+			if a.AuthorPosition != "" {
+				fmt.Sscanf(a.AuthorPosition, "%d", &position)
+			}
+
+			osAuthors[k] = opensearch.OSAuthor{
+				AuthorID:           a.AuthorID,
+				AuthorName:         a.AuthorName,
+				AuthorNameVariants: a.AuthorAvailableNames,
+				AuthorPosition:     position,
+				AuthorAffiliation:  a.AuthorAffiliation,
+				AuthorEmail:        a.AuthorEmail,
+				HasMatchedProfile:  a.MatchedProfile != nil,
+			}
+		}
+
+		osDocs[i] = opensearch.OSDocument{
+			MongoID:            doc.ID.Hex(),
+			Title:              doc.Title,
+			Abstract:           doc.Abstract,
+			Authors:            osAuthors,
+			AuthorNames:        authorNames,
+			AuthorNameVariants: allVariants,
+			PublicationYear:    doc.PublicationYear,
+			FieldAssociated:    doc.FieldAssociated,
+			DocumentType:       doc.DocumentType,
+			SubjectArea:        doc.SubjectArea,
+			SubjectAreaCount:   len(doc.SubjectArea),
+			CitationCount:      doc.CitationCount,
+			ReferenceCount:     doc.ReferenceCount,
+			Embedding:          embeddings[i],
+		}
+	}
+
+	idMap, err := idx.openSearch.BulkIndex(ctx, osDocs)
+	if err != nil {
+		idx.cli.Warning(fmt.Sprintf("Bulk index failed for %d changed documents: %v", len(docs), err))
+		return
+	}
+
+	var updates []mongodb.IDUpdate
+	for _, doc := range docs {
+		if osID, ok := idMap[doc.ID.Hex()]; ok {
+			osIDs.Store(doc.ID.Hex(), osID)
+			updates = append(updates, mongodb.IDUpdate{MongoID: doc.ID, OpenSearchID: osID})
+		}
+	}
+	if len(updates) > 0 {
+		if err := idx.mongoDB.BulkUpdateOpenSearchIDs(ctx, updates, idx.lease); err != nil {
+			idx.cli.Warning(fmt.Sprintf("MongoDB update failed for %d changed documents: %v", len(updates), err))
+		}
+	}
+	idx.cli.Success(fmt.Sprintf("Upserted %d changed documents to OpenSearch", len(idMap)))
+}
+
+// loadResumeToken reads the change stream resume token persisted by a
+// previous run, returning (nil, nil) if none exists yet.
+func (idx *Indexer) loadResumeToken() (bson.Raw, error) {
+	path := filepath.Join(idx.cfg.CacheDir, resumeTokenFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return bson.Raw(data), nil
+}
+
+// saveResumeToken persists the change stream resume token to the cache dir
+// so a restart resumes from here instead of missing events.
+func (idx *Indexer) saveResumeToken(token bson.Raw) error {
+	if token == nil {
+		return nil
+	}
+	path := filepath.Join(idx.cfg.CacheDir, resumeTokenFileName)
+	return os.WriteFile(path, token, 0644)
+}
@@ -2,7 +2,14 @@ package indexer
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	errors2 "errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,21 +20,83 @@ import (
 	"github.com/sudarshan/indexer/internal/embedding"
 	"github.com/sudarshan/indexer/internal/mongodb"
 	"github.com/sudarshan/indexer/internal/opensearch"
+	"github.com/sudarshan/indexer/internal/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Indexer handles batch indexing from MongoDB to OpenSearch
 type Indexer struct {
-	cfg         *config.Config
-	mongoDB     *mongodb.Client
-	openSearch  *opensearch.Client
-	embedClient *embedding.Client
-	cache       *cache.Cache
-	cli         *cli.CLI
+	cfg           *config.Config
+	mongoDB       *mongodb.Client
+	openSearch    *opensearch.Client // non-nil only when cfg.SearchBackend is "opensearch" (the default)
+	searchBackend search.Backend
+	embedClient   embedding.Provider
+	batchSizer    *embedding.BatchSizer
+	cache         cache.Backend
+	cli           *cli.CLI
+	lease         *mongodb.Lease // non-nil only when cfg.LeaseEnabled
+	tenantID      string         // non-empty only when cfg.TenantIDs is in use
 }
 
-// NewForPhase1 creates an Indexer for Phase 1 (only MongoDB + embedding needed)
-func NewForPhase1(cfg *config.Config, quiet bool) (*Indexer, error) {
-	output := cli.New(quiet)
+// newSearchBackend builds the search.Backend selected by cfg.SearchBackend
+// ("opensearch" by default). For the OpenSearch backend it also returns
+// the concrete *opensearch.Client it wraps, since some operations (index
+// generations, alias swaps, mapping-version reindexes - nothing Bleve has
+// an analog for) stay outside the Backend interface and are driven
+// directly against the client; osClient is nil for every other backend.
+func newSearchBackend(cfg *config.Config) (backend search.Backend, osClient *opensearch.Client, err error) {
+	switch cfg.SearchBackend {
+	case "", "opensearch":
+		osClient, err = opensearch.NewClient(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opensearch connect: %w", err)
+		}
+		return search.NewOpenSearchBackend(osClient), osClient, nil
+	case "bleve":
+		backend, err = search.NewBleveBackend()
+		if err != nil {
+			return nil, nil, fmt.Errorf("bleve init: %w", err)
+		}
+		return backend, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown search backend %q", cfg.SearchBackend)
+	}
+}
+
+// searchBackendName returns cfg.SearchBackend for logging, defaulting to
+// "opensearch" to match newSearchBackend's own default case.
+func searchBackendName(cfg *config.Config) string {
+	if cfg.SearchBackend == "" {
+		return "opensearch"
+	}
+	return cfg.SearchBackend
+}
+
+// acquireLease takes the indexer_leases lease when cfg.LeaseEnabled, so
+// StreamDocuments and BulkUpdateOpenSearchIDs can coordinate against a
+// second indexer replica. Returns a nil *mongodb.Lease (not an error) when
+// the feature is off, which both of those calls treat as "no coordination".
+func acquireLease(cfg *config.Config, mongoDB *mongodb.Client, output *cli.CLI) (*mongodb.Lease, error) {
+	if !cfg.LeaseEnabled {
+		return nil, nil
+	}
+
+	output.Info("Acquiring indexer lease...")
+	ttl := time.Duration(cfg.LeaseTTLSeconds) * time.Second
+	lease, err := mongoDB.AcquireLease(context.Background(), ttl)
+	if err != nil {
+		return nil, fmt.Errorf("acquire indexer lease: %w", err)
+	}
+	output.Success(fmt.Sprintf("Indexer lease acquired (fence %d)", lease.Fence()))
+	return lease, nil
+}
+
+// NewForPhase1 creates an Indexer for Phase 1 (only MongoDB + embedding
+// needed). tenantID, if non-empty, scopes every MongoDB query this Indexer
+// issues and namespaces its cache dir to that tenant (see
+// cache.NewBackendForTenant); pass "" for single-tenant deployments.
+func NewForPhase1(cfg *config.Config, quiet bool, tenantID string) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
 
 	output.Info("Connecting to MongoDB...")
 	mongoDB, err := mongodb.NewClient(cfg)
@@ -37,29 +106,54 @@ func NewForPhase1(cfg *config.Config, quiet bool) (*Indexer, error) {
 	output.Success("Connected to MongoDB")
 
 	output.Info("Initializing embedding client...")
-	embedClient := embedding.NewClient(cfg)
-	output.Success("Embedding client initialized")
+	embedClient, err := newEmbeddingProvider(cfg)
+	if err != nil {
+		mongoDB.Close(context.Background())
+		return nil, fmt.Errorf("embedding provider init: %w", err)
+	}
+	output.Success(fmt.Sprintf("Embedding provider initialized: %s", embedClient.Name()))
 
 	output.Info("Setting up cache...")
-	c, err := cache.NewCache(cfg.CacheDir)
+	c, err := cache.NewBackendForTenant(cfg, tenantID)
 	if err != nil {
 		mongoDB.Close(context.Background())
 		return nil, fmt.Errorf("cache init: %w", err)
 	}
-	output.Success(fmt.Sprintf("Cache directory: %s", cfg.CacheDir))
+	output.Success(fmt.Sprintf("Cache directory: %s", cache.TenantCacheDir(cfg.CacheDir, tenantID)))
+
+	lease, err := acquireLease(cfg, mongoDB, output)
+	if err != nil {
+		mongoDB.Close(context.Background())
+		return nil, err
+	}
 
 	return &Indexer{
 		cfg:         cfg,
 		mongoDB:     mongoDB,
 		embedClient: embedClient,
+		batchSizer:  embedding.NewBatchSizer(cfg),
 		cache:       c,
 		cli:         output,
+		lease:       lease,
+		tenantID:    tenantID,
 	}, nil
 }
 
-// NewForPhase2 creates an Indexer for Phase 2 (only OpenSearch + MongoDB needed)
-func NewForPhase2(cfg *config.Config, quiet bool) (*Indexer, error) {
-	output := cli.New(quiet)
+// newEmbeddingProvider builds the configured embedding.Provider and, unless
+// disabled, wraps it with the on-disk content-hash cache so unchanged
+// title/abstract pairs never round-trip to the embedding service twice.
+func newEmbeddingProvider(cfg *config.Config) (embedding.Provider, error) {
+	provider, err := embedding.NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return embedding.NewCachingProvider(cfg, provider)
+}
+
+// NewForPhase2 creates an Indexer for Phase 2 (only OpenSearch + MongoDB
+// needed). See NewForPhase1 for tenantID.
+func NewForPhase2(cfg *config.Config, quiet bool, tenantID string) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
 
 	output.Info("Connecting to MongoDB...")
 	mongoDB, err := mongodb.NewClient(cfg)
@@ -68,34 +162,44 @@ func NewForPhase2(cfg *config.Config, quiet bool) (*Indexer, error) {
 	}
 	output.Success("Connected to MongoDB")
 
-	output.Info("Connecting to OpenSearch...")
-	osClient, err := opensearch.NewClient(cfg)
+	output.Info(fmt.Sprintf("Connecting to search backend (%s)...", searchBackendName(cfg)))
+	searchBackend, osClient, err := newSearchBackend(cfg)
 	if err != nil {
 		mongoDB.Close(context.Background())
-		return nil, fmt.Errorf("opensearch connect: %w", err)
+		return nil, err
 	}
-	output.Success("Connected to OpenSearch")
+	output.Success("Search backend ready")
 
 	output.Info("Setting up cache...")
-	c, err := cache.NewCache(cfg.CacheDir)
+	c, err := cache.NewBackendForTenant(cfg, tenantID)
 	if err != nil {
 		mongoDB.Close(context.Background())
 		return nil, fmt.Errorf("cache init: %w", err)
 	}
-	output.Success(fmt.Sprintf("Cache directory: %s", cfg.CacheDir))
+	output.Success(fmt.Sprintf("Cache directory: %s", cache.TenantCacheDir(cfg.CacheDir, tenantID)))
+
+	lease, err := acquireLease(cfg, mongoDB, output)
+	if err != nil {
+		mongoDB.Close(context.Background())
+		return nil, err
+	}
 
 	return &Indexer{
-		cfg:        cfg,
-		mongoDB:    mongoDB,
-		openSearch: osClient,
-		cache:      c,
-		cli:        output,
+		cfg:           cfg,
+		mongoDB:       mongoDB,
+		openSearch:    osClient,
+		searchBackend: searchBackend,
+		cache:         c,
+		cli:           output,
+		lease:         lease,
+		tenantID:      tenantID,
 	}, nil
 }
 
-// New creates a full Indexer with all connections (for run, reindex-full)
-func New(cfg *config.Config, quiet bool) (*Indexer, error) {
-	output := cli.New(quiet)
+// New creates a full Indexer with all connections (for run, reindex-full).
+// See NewForPhase1 for tenantID.
+func New(cfg *config.Config, quiet bool, tenantID string) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
 
 	output.Info("Connecting to MongoDB...")
 	mongoDB, err := mongodb.NewClient(cfg)
@@ -113,40 +217,134 @@ func New(cfg *config.Config, quiet bool) (*Indexer, error) {
 	output.Success("Connected to OpenSearch")
 
 	output.Info("Initializing embedding client...")
-	embedClient := embedding.NewClient(cfg)
-	output.Success("Embedding client initialized")
+	embedClient, err := newEmbeddingProvider(cfg)
+	if err != nil {
+		mongoDB.Close(context.Background())
+		osClient.Close()
+		return nil, fmt.Errorf("embedding provider init: %w", err)
+	}
+	output.Success(fmt.Sprintf("Embedding provider initialized: %s", embedClient.Name()))
 
 	output.Info("Setting up cache...")
-	c, err := cache.NewCache(cfg.CacheDir)
+	c, err := cache.NewBackendForTenant(cfg, tenantID)
 	if err != nil {
 		mongoDB.Close(context.Background())
 		return nil, fmt.Errorf("cache init: %w", err)
 	}
-	output.Success(fmt.Sprintf("Cache directory: %s", cfg.CacheDir))
+	output.Success(fmt.Sprintf("Cache directory: %s", cache.TenantCacheDir(cfg.CacheDir, tenantID)))
+
+	lease, err := acquireLease(cfg, mongoDB, output)
+	if err != nil {
+		mongoDB.Close(context.Background())
+		osClient.Close()
+		return nil, err
+	}
 
 	return &Indexer{
 		cfg:         cfg,
 		mongoDB:     mongoDB,
 		openSearch:  osClient,
 		embedClient: embedClient,
+		batchSizer:  embedding.NewBatchSizer(cfg),
 		cache:       c,
 		cli:         output,
+		lease:       lease,
+		tenantID:    tenantID,
 	}, nil
 }
 
-// NewCacheOnly creates an Indexer with only cache access (for status, clean)
-func NewCacheOnly(cfg *config.Config, quiet bool) (*Indexer, error) {
-	output := cli.New(quiet)
+// NewMongoOnly creates an Indexer with only a MongoDB connection, for
+// --migrate-only: NewClient already runs pending schema migrations as part
+// of connecting, so this is enough to preflight them without touching the
+// embedding service or OpenSearch.
+func NewMongoOnly(cfg *config.Config, quiet bool) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
 
-	c, err := cache.NewCache(cfg.CacheDir)
+	output.Info("Connecting to MongoDB...")
+	mongoDB, err := mongodb.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("mongodb connect: %w", err)
+	}
+	output.Success("Connected to MongoDB")
+
+	return &Indexer{
+		cfg:     cfg,
+		mongoDB: mongoDB,
+		cli:     output,
+	}, nil
+}
+
+// NewCacheOnly creates an Indexer with only cache access (for clean). See
+// NewForPhase1 for tenantID.
+func NewCacheOnly(cfg *config.Config, quiet bool, tenantID string) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
+
+	c, err := cache.NewBackendForTenant(cfg, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("cache init: %w", err)
 	}
 
 	return &Indexer{
-		cfg:   cfg,
-		cache: c,
-		cli:   output,
+		cfg:      cfg,
+		cache:    c,
+		cli:      output,
+		tenantID: tenantID,
+	}, nil
+}
+
+// NewForStatus creates an Indexer for the status command: cache access plus
+// a best-effort search backend connection, so CacheStatus can also surface
+// cluster health. Unlike every other constructor, a search backend connect
+// failure here is non-fatal - status is meant to work (and report "down")
+// even while OpenSearch itself is down.
+func NewForStatus(cfg *config.Config, quiet bool, tenantID string) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
+
+	c, err := cache.NewBackendForTenant(cfg, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("cache init: %w", err)
+	}
+
+	searchBackend, osClient, err := newSearchBackend(cfg)
+	if err != nil {
+		output.Warning(fmt.Sprintf("Search backend unavailable: %v", err))
+	}
+
+	return &Indexer{
+		cfg:           cfg,
+		openSearch:    osClient,
+		searchBackend: searchBackend,
+		cache:         c,
+		cli:           output,
+		tenantID:      tenantID,
+	}, nil
+}
+
+// NewForSearch creates an Indexer for ad-hoc hybrid search queries (only
+// OpenSearch + embedding needed, no MongoDB/cache).
+func NewForSearch(cfg *config.Config, quiet bool) (*Indexer, error) {
+	output := cli.New(quiet, cfg.Output)
+
+	output.Info(fmt.Sprintf("Connecting to search backend (%s)...", searchBackendName(cfg)))
+	searchBackend, osClient, err := newSearchBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	output.Success("Search backend ready")
+
+	output.Info("Initializing embedding client...")
+	embedClient, err := newEmbeddingProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("embedding provider init: %w", err)
+	}
+	output.Success(fmt.Sprintf("Embedding provider initialized: %s", embedClient.Name()))
+
+	return &Indexer{
+		cfg:           cfg,
+		openSearch:    osClient,
+		searchBackend: searchBackend,
+		embedClient:   embedClient,
+		cli:           output,
 	}, nil
 }
 
@@ -155,19 +353,34 @@ func (idx *Indexer) Close() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if idx.lease != nil {
+		idx.lease.Release()
+	}
 	if idx.mongoDB != nil {
 		idx.mongoDB.Close(ctx)
 	}
-	if idx.openSearch != nil {
+	if idx.searchBackend != nil {
+		idx.searchBackend.Close()
+	} else if idx.openSearch != nil {
 		idx.openSearch.Close()
 	}
 	idx.cli.Success("Connections closed")
 }
 
+// phaseName prefixes name with this Indexer's tenant, if any, so a
+// multi-tenant run's CLI output (or JSON event stream) distinguishes which
+// tenant each phase belongs to.
+func (idx *Indexer) phaseName(name string) string {
+	if idx.tenantID == "" {
+		return name
+	}
+	return fmt.Sprintf("[%s] %s", idx.tenantID, name)
+}
+
 // Phase1FetchAndEmbed fetches documents from MongoDB and generates embeddings
 // Results are cached to disk for Phase 2
 func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexAll bool) error {
-	idx.cli.StartPhase("Phase 1: Fetch & Embed")
+	idx.cli.StartPhase(idx.phaseName("Phase 1: Fetch & Embed"))
 
 	// Step 1: Load existing cache if resuming
 	idx.cli.Step(1, 5, "Loading cache")
@@ -180,9 +393,26 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 		}
 	}
 
+	// Snapshot the previous generation's entries by Mongo ID before a
+	// reindex clears the cache below, so content-hash dedup can still reuse
+	// embeddings for documents whose title/abstract haven't changed even
+	// across a full reindex.
+	priorByMongoID := make(map[string]cache.CacheEntry, idx.cache.Count())
+	for _, e := range idx.cache.GetEntries() {
+		priorByMongoID[e.MongoID.Hex()] = e
+	}
+
+	if reindexAll {
+		// A full reindex restreams every document regardless of what's
+		// cached; content-hash dedup below decides what actually needs
+		// re-embedding, so stale processed-ID bookkeeping from the previous
+		// generation shouldn't make anything skip that check.
+		idx.cache.Clear()
+	}
+
 	// Step 2: Count documents
 	idx.cli.Step(2, 5, "Counting documents to process")
-	total, err := idx.mongoDB.CountDocumentsToIndex(ctx, reindexAll)
+	total, err := idx.mongoDB.CountDocumentsToIndex(ctx, reindexAll, idx.tenantID)
 	if err != nil {
 		return fmt.Errorf("count documents: %w", err)
 	}
@@ -203,7 +433,7 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 
 	// Step 3: Stream documents
 	idx.cli.Step(3, 5, "Streaming documents from MongoDB")
-	docChan, err := idx.mongoDB.StreamDocuments(ctx, reindexAll, limit)
+	docChan, err := idx.mongoDB.StreamDocuments(ctx, reindexAll, limit, idx.lease, idx.tenantID)
 	if err != nil {
 		return fmt.Errorf("stream documents: %w", err)
 	}
@@ -213,12 +443,15 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 
 	// Step 4: Generate embeddings
 	idx.cli.Step(4, 5, "Generating embeddings")
-	idx.cli.Running(fmt.Sprintf("Using %d workers with batch size %d", idx.cfg.NumWorkers, idx.cfg.EmbedBatchSize))
+	idx.cli.Running(fmt.Sprintf("Using %d workers with batch size up to %d (adaptive)", idx.cfg.NumWorkers, idx.batchSizer.Size()))
 
 	var (
 		processed int64
 		errors    int64
 		skipped   int64
+		parked    int64
+		hashHits  int64
+		hashMiss  int64
 	)
 
 	// Create progress tracker
@@ -233,8 +466,10 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 		batch := make([]mongodb.Document, 0, idx.cfg.MongoBatchSize)
 
 		for doc := range docChan {
-			// Skip if already cached
-			if idx.cache.IsProcessed(doc.ID.Hex()) {
+			// Skip if already cached in this generation. During a full
+			// reindex every document must still pass through to the
+			// content-hash check below, so this only applies otherwise.
+			if !reindexAll && idx.cache.IsProcessed(doc.ID.Hex()) {
 				atomic.AddInt64(&skipped, 1)
 				continue
 			}
@@ -295,25 +530,69 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 				default:
 				}
 
-				// Build embedding texts
+				// Build embedding texts and content hashes, and reuse the
+				// previous generation's embedding wherever the hash matches
+				// instead of re-embedding unchanged documents.
 				texts := make([]string, len(docs))
+				hashes := make([]string, len(docs))
+				embeddings := make([][]float32, len(docs))
+				var toEmbed []int
 				for i, doc := range docs {
-					texts[i] = embedding.BuildEmbeddingText(doc.Title, doc.Abstract)
+					text := idx.embedClient.BuildText(doc.Title, doc.Abstract)
+					texts[i] = text
+					hashes[i] = contentHash(text)
+
+					if prior, ok := priorByMongoID[doc.ID.Hex()]; ok && prior.ContentHash == hashes[i] {
+						embeddings[i] = prior.Embedding
+						atomic.AddInt64(&hashHits, 1)
+						continue
+					}
+					atomic.AddInt64(&hashMiss, 1)
+					toEmbed = append(toEmbed, i)
 				}
 
-				// Get embeddings in sub-batches
-				allEmbeddings := make([][]float32, 0, len(docs))
+				toEmbedTexts := make([]string, len(toEmbed))
+				for j, i := range toEmbed {
+					toEmbedTexts[j] = texts[i]
+				}
+
+				// Get embeddings in sub-batches, sized adaptively: shrink
+				// under sustained rate-limit pressure, grow back on success.
+				fetched := make([][]float32, 0, len(toEmbedTexts))
 				failed := false
 
-				for i := 0; i < len(texts); i += idx.cfg.EmbedBatchSize {
-					end := min(i+idx.cfg.EmbedBatchSize, len(texts))
-					embeddings, err := idx.embedClient.GetEmbeddings(ctx, texts[i:end])
+				for i := 0; i < len(toEmbedTexts); {
+					batchSize := idx.batchSizer.Size()
+					end := min(i+batchSize, len(toEmbedTexts))
+					batchEmbeddings, err := idx.embedClient.GetEmbeddings(ctx, toEmbedTexts[i:end])
 					if err != nil {
-						atomic.AddInt64(&errors, int64(len(docs)))
+						docsNeedingEmbed := make([]mongodb.Document, len(toEmbed))
+						for j, di := range toEmbed {
+							docsNeedingEmbed[j] = docs[di]
+						}
+						if embedding.IsRateLimited(err) {
+							idx.batchSizer.Shrink()
+						}
+						if errors2.Is(err, embedding.ErrCircuitOpen) {
+							// The embedding service is known to be down: park
+							// these docs on disk as a dead-letter record
+							// instead of burning retries on them right now.
+							// Nothing reads parked.jsonl back; these docs
+							// stay uncached, so a later non-reindexAll run
+							// re-streams and retries them the normal way.
+							if parkErr := idx.parkDocs(docsNeedingEmbed); parkErr != nil {
+								idx.cli.Warning(fmt.Sprintf("Failed to park batch during circuit-open: %v", parkErr))
+							}
+							atomic.AddInt64(&parked, int64(len(docsNeedingEmbed)))
+						} else {
+							atomic.AddInt64(&errors, int64(len(docsNeedingEmbed)))
+						}
 						failed = true
 						break
 					}
-					allEmbeddings = append(allEmbeddings, embeddings...)
+					idx.batchSizer.Grow()
+					fetched = append(fetched, batchEmbeddings...)
+					i = end
 				}
 
 				if failed {
@@ -321,6 +600,10 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 					continue
 				}
 
+				for j, i := range toEmbed {
+					embeddings[i] = fetched[j]
+				}
+
 				// Create cache entries
 				entries := make([]cache.CacheEntry, len(docs))
 				for i, doc := range docs {
@@ -348,7 +631,8 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 						SubjectArea:     doc.SubjectArea,
 						CitationCount:   doc.CitationCount,
 						ReferenceCount:  doc.ReferenceCount,
-						Embedding:       allEmbeddings[i],
+						Embedding:       embeddings[i],
+						ContentHash:     hashes[i],
 					}
 				}
 
@@ -385,10 +669,17 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 	elapsed := idx.cli.EndPhase()
 
 	// Print summary
+	hashTotal := hashHits + hashMiss
+	hashHitPct := 0.0
+	if hashTotal > 0 {
+		hashHitPct = float64(hashHits) / float64(hashTotal) * 100
+	}
 	idx.cli.Summary("Phase 1 Complete", map[string]string{
 		"Processed":  fmt.Sprintf("%d", processed),
 		"Errors":     fmt.Sprintf("%d", errors),
+		"Parked":     fmt.Sprintf("%d (embedding circuit open)", parked),
 		"Skipped":    fmt.Sprintf("%d (already cached)", skipped),
+		"Cache Hits": fmt.Sprintf("%d/%d (%.1f%%, reused from prior generation)", hashHits, hashTotal, hashHitPct),
 		"Total Time": elapsed.String(),
 		"Rate":       fmt.Sprintf("%.1f docs/sec", float64(processed)/elapsed.Seconds()),
 	})
@@ -396,9 +687,62 @@ func (idx *Indexer) Phase1FetchAndEmbed(ctx context.Context, limit int, reindexA
 	return nil
 }
 
+// contentHash returns a SHA-256 hex digest of the exact text sent for
+// embedding, so Phase 1 can tell whether a document's embedding is still
+// valid without re-calling the embedding service.
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// parkedDoc is the on-disk representation of a document that couldn't be
+// embedded because the circuit breaker was open. It's a dead-letter record
+// for operators to inspect, not an input to any automated replay: nothing
+// in this package reads parked.jsonl back. A parked document is never
+// marked processed in idx.cache, so it's still picked up the normal way —
+// re-streamed and re-embedded — the next time Phase 1 runs without
+// reindexAll.
+type parkedDoc struct {
+	MongoID  string `json:"mongo_id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+}
+
+// parkDocs appends documents skipped due to an open embedding circuit
+// breaker to a write-only dead-letter file in the cache dir, for operators
+// to inspect after the fact.
+func (idx *Indexer) parkDocs(docs []mongodb.Document) error {
+	path := filepath.Join(idx.cfg.CacheDir, "parked.jsonl")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, doc := range docs {
+		if err := enc.Encode(parkedDoc{
+			MongoID:  doc.ID.Hex(),
+			Title:    doc.Title,
+			Abstract: doc.Abstract,
+		}); err != nil {
+			return fmt.Errorf("write parked doc: %w", err)
+		}
+	}
+	return nil
+}
+
 // Phase2IndexAndUpdate reads from cache and indexes to OpenSearch
 func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
-	idx.cli.StartPhase("Phase 2: Index & Update")
+	return idx.phase2IndexAndUpdateInto(ctx, idx.cfg.OpenSearchIndex)
+}
+
+// phase2IndexAndUpdateInto is Phase2IndexAndUpdate against an explicit
+// backing index instead of the configured alias, so ReindexFull can drive
+// it against a new generation before that generation is aliased into
+// place.
+func (idx *Indexer) phase2IndexAndUpdateInto(ctx context.Context, targetIndex string) error {
+	idx.cli.StartPhase(idx.phaseName("Phase 2: Index & Update"))
 
 	// Step 1: Load cache
 	idx.cli.Step(1, 4, "Loading cache")
@@ -414,128 +758,56 @@ func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
 	}
 	idx.cli.Info(fmt.Sprintf("Loaded %d entries from cache", len(entries)))
 
+	checkpoint, err := idx.loadPhase2Checkpoint()
+	if err != nil {
+		idx.cli.Warning(fmt.Sprintf("Could not load checkpoint: %v (starting fresh)", err))
+		checkpoint = &phase2Checkpoint{States: make(map[string]entryState)}
+	} else if len(checkpoint.States) > 0 {
+		idx.cli.Info(fmt.Sprintf("Resuming from checkpoint: %d entries already settled", len(checkpoint.States)))
+	}
+
 	// Step 2: Ensure index exists
-	idx.cli.Step(2, 4, "Checking OpenSearch index")
-	if err := idx.openSearch.CreateIndex(ctx); err != nil {
+	idx.cli.Step(2, 4, "Checking search index")
+	if idx.openSearch != nil {
+		if err := idx.openSearch.CreateIndexNamed(ctx, targetIndex); err != nil {
+			return fmt.Errorf("ensure index: %w", err)
+		}
+	} else if err := idx.searchBackend.EnsureIndex(ctx); err != nil {
 		return fmt.Errorf("ensure index: %w", err)
 	}
 
-	// Step 3: Index to OpenSearch
-	idx.cli.Step(3, 4, "Indexing to OpenSearch")
-	idx.cli.Running(fmt.Sprintf("Bulk indexing with batch size %d", idx.cfg.OpenSearchBulkSize))
+	// Step 3: Index to the search backend. The OpenSearch backend streams
+	// through a BulkIndexer so indexing isn't bottlenecked on a per-batch
+	// round trip and a single non-retryable document can't fail the batch
+	// around it; other backends don't have that machinery, so they go
+	// through a simpler batched loop instead.
+	idx.cli.Step(3, 4, "Indexing to search backend")
 
 	var (
-		indexed int64
-		errors  int64
+		indexed      int64
+		errors       int64
+		mongoUpdates []mongodb.IDUpdate
+		deadLetters  []deadLetterEntry
 	)
 
-	progress := cli.NewProgress(int64(len(entries)))
-
-	// Progress ticker
-	progressCtx, cancelProgress := context.WithCancel(ctx)
-	progressTicker := time.NewTicker(500 * time.Millisecond)
-
-	go func() {
-		defer progressTicker.Stop()
-		for {
-			select {
-			case <-progressTicker.C:
-				idx.cli.Progress(progress)
-			case <-progressCtx.Done():
-				return
-			}
-		}
-	}()
-
-	// Collect MongoDB updates
-	var mongoUpdates []mongodb.IDUpdate
-
-	// Process in batches
-	for i := 0; i < len(entries); i += idx.cfg.OpenSearchBulkSize {
-		select {
-		case <-ctx.Done():
-			cancelProgress()
-			return ctx.Err()
-		default:
-		}
-
-		end := min(i+idx.cfg.OpenSearchBulkSize, len(entries))
-		batch := entries[i:end]
-
-		// Build OpenSearch documents
-		osDocs := make([]opensearch.OSDocument, len(batch))
-		for j, entry := range batch {
-			// Build author structures
-			osAuthors := make([]opensearch.OSAuthor, len(entry.Authors))
-			authorNames := make([]string, len(entry.Authors))
-			allVariants := make([]string, 0)
-
-			for k, a := range entry.Authors {
-				authorNames[k] = a.AuthorName
-				if len(a.AuthorAvailableNames) > 0 {
-					allVariants = append(allVariants, a.AuthorAvailableNames...)
-				}
-
-				position := 0
-				if a.AuthorPosition != "" {
-					fmt.Sscanf(a.AuthorPosition, "%d", &position)
-				}
-
-				osAuthors[k] = opensearch.OSAuthor{
-					AuthorID:           a.AuthorID,
-					AuthorName:         a.AuthorName,
-					AuthorNameVariants: a.AuthorAvailableNames,
-					AuthorPosition:     position,
-					AuthorAffiliation:  a.AuthorAffiliation,
-					AuthorEmail:        a.AuthorEmail,
-					HasMatchedProfile:  a.HasMatchedProfile,
-				}
-			}
-
-			osDocs[j] = opensearch.OSDocument{
-				MongoID:            entry.MongoID.Hex(),
-				Title:              entry.Title,
-				Abstract:           entry.Abstract,
-				Authors:            osAuthors,
-				AuthorNames:        authorNames,
-				AuthorNameVariants: allVariants,
-				PublicationYear:    entry.PublicationYear,
-				FieldAssociated:    entry.FieldAssociated,
-				DocumentType:       entry.DocumentType,
-				SubjectArea:        entry.SubjectArea,
-				SubjectAreaCount:   len(entry.SubjectArea),
-				CitationCount:      entry.CitationCount,
-				ReferenceCount:     entry.ReferenceCount,
-				Embedding:          entry.Embedding,
-			}
-		}
-
-		// Bulk index
-		idMap, err := idx.openSearch.BulkIndex(ctx, osDocs)
-		if err != nil {
-			errors += int64(len(batch))
-			progress.Update(int64(len(batch)))
-			continue
-		}
-
-		indexed += int64(len(idMap))
-		errors += int64(len(batch) - len(idMap))
-		progress.Update(int64(len(batch)))
+	if idx.openSearch != nil {
+		indexed, errors, mongoUpdates, deadLetters, err = idx.phase2BulkIndexOpenSearch(ctx, targetIndex, entries, checkpoint)
+	} else {
+		indexed, errors, mongoUpdates, deadLetters, err = idx.phase2BulkIndexGeneric(ctx, entries, checkpoint)
+	}
+	if err != nil {
+		return err
+	}
 
-		// Collect MongoDB updates
-		for _, entry := range batch {
-			if osID, ok := idMap[entry.MongoID.Hex()]; ok {
-				mongoUpdates = append(mongoUpdates, mongodb.IDUpdate{
-					MongoID:      entry.MongoID,
-					OpenSearchID: osID,
-				})
-			}
-		}
+	if err := idx.appendDeadLetters(deadLetters); err != nil {
+		idx.cli.Warning(fmt.Sprintf("Failed to write dead letters: %v", err))
+	}
+	checkpoint.LastOffset = len(entries)
+	if err := idx.savePhase2Checkpoint(checkpoint); err != nil {
+		idx.cli.Warning(fmt.Sprintf("Could not persist checkpoint: %v", err))
 	}
 
-	cancelProgress()
-	idx.cli.ProgressDone()
-	idx.cli.Success(fmt.Sprintf("Indexed %d documents to OpenSearch", indexed))
+	idx.cli.Success(fmt.Sprintf("Indexed %d documents to search backend", indexed))
 
 	// Step 4: Update MongoDB
 	idx.cli.Step(4, 4, "Updating MongoDB")
@@ -553,6 +825,8 @@ func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
 		for {
 			select {
 			case <-mongoProgressTicker.C:
+				rate, inflight, errs, throttled := idx.mongoDB.RateStats()
+				mongoProgress.SetRateStats(&cli.RateStats{Label: "writes", Rate: rate, Inflight: inflight, Errors: errs, Throttled: throttled})
 				idx.cli.Progress(mongoProgress)
 			case <-mongoProgressCtx.Done():
 				return
@@ -565,7 +839,7 @@ func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
 		end := min(i+idx.cfg.OpenSearchBulkSize, len(mongoUpdates))
 		batch := mongoUpdates[i:end]
 
-		if err := idx.mongoDB.BulkUpdateOpenSearchIDs(ctx, batch); err != nil {
+		if err := idx.mongoDB.BulkUpdateOpenSearchIDs(ctx, batch, idx.lease); err != nil {
 			idx.cli.Warning(fmt.Sprintf("MongoDB update batch failed: %v", err))
 		}
 		mongoProgress.Update(int64(len(batch)))
@@ -575,12 +849,16 @@ func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
 	idx.cli.ProgressDone()
 	idx.cli.Success(fmt.Sprintf("Updated %d MongoDB documents", len(mongoUpdates)))
 
+	if errors == 0 {
+		idx.clearPhase2Checkpoint()
+	}
+
 	elapsed := idx.cli.EndPhase()
 
 	// Print summary
 	idx.cli.Summary("Phase 2 Complete", map[string]string{
 		"Indexed":    fmt.Sprintf("%d", indexed),
-		"Errors":     fmt.Sprintf("%d", errors),
+		"Errors":     fmt.Sprintf("%d (see dead-letter.jsonl)", errors),
 		"MongoDB":    fmt.Sprintf("%d updated", len(mongoUpdates)),
 		"Total Time": elapsed.String(),
 		"Rate":       fmt.Sprintf("%.1f docs/sec", float64(indexed)/elapsed.Seconds()),
@@ -589,6 +867,234 @@ func (idx *Indexer) Phase2IndexAndUpdate(ctx context.Context) error {
 	return nil
 }
 
+// phase2BulkIndexOpenSearch feeds entries through a streaming BulkIndexer
+// against targetIndex, the indexing path used when idx.openSearch is set.
+func (idx *Indexer) phase2BulkIndexOpenSearch(ctx context.Context, targetIndex string, entries []cache.CacheEntry, checkpoint *phase2Checkpoint) (indexed, errors int64, mongoUpdates []mongodb.IDUpdate, deadLetters []deadLetterEntry, err error) {
+	idx.cli.Running(fmt.Sprintf("Bulk indexing with %d workers, flushing at %d bytes / %v",
+		idx.cfg.BulkWorkers, idx.cfg.BulkFlushBytes, time.Duration(idx.cfg.BulkFlushIntervalMs)*time.Millisecond))
+
+	var resultsMu sync.Mutex
+	progress := cli.NewProgress(int64(len(entries)))
+
+	// Progress ticker
+	progressCtx, cancelProgress := context.WithCancel(ctx)
+	defer cancelProgress()
+	progressTicker := time.NewTicker(500 * time.Millisecond)
+
+	go func() {
+		defer progressTicker.Stop()
+		for {
+			select {
+			case <-progressTicker.C:
+				idx.cli.Progress(progress)
+			case <-progressCtx.Done():
+				return
+			}
+		}
+	}()
+
+	bulkIndexer := idx.openSearch.NewBulkIndexer(opensearch.BulkIndexerConfig{
+		Index:         targetIndex,
+		FlushBytes:    idx.cfg.BulkFlushBytes,
+		FlushInterval: time.Duration(idx.cfg.BulkFlushIntervalMs) * time.Millisecond,
+		NumWorkers:    idx.cfg.BulkWorkers,
+		MaxRetries:    idx.cfg.MaxRetries,
+		OnSuccess: func(doc opensearch.OSDocument, osID string) {
+			mongoID, err := primitive.ObjectIDFromHex(doc.MongoID)
+			if err != nil {
+				return
+			}
+			resultsMu.Lock()
+			checkpoint.States[doc.MongoID] = entryIndexed
+			mongoUpdates = append(mongoUpdates, mongodb.IDUpdate{
+				MongoID:      mongoID,
+				OpenSearchID: osID,
+				TenantID:     idx.tenantID,
+			})
+			resultsMu.Unlock()
+			atomic.AddInt64(&indexed, 1)
+			progress.Update(1)
+		},
+		OnFailure: func(doc opensearch.OSDocument, res *opensearch.BulkItemResult, err error) {
+			reason := "retries exhausted"
+			switch {
+			case res != nil:
+				reason = res.ErrorReason
+			case err != nil:
+				reason = err.Error()
+			}
+			resultsMu.Lock()
+			checkpoint.States[doc.MongoID] = entryFailed
+			deadLetters = append(deadLetters, deadLetterEntry{
+				MongoID:  doc.MongoID,
+				Title:    doc.Title,
+				Error:    reason,
+				FailedAt: time.Now(),
+			})
+			resultsMu.Unlock()
+			atomic.AddInt64(&errors, 1)
+			progress.Update(1)
+		},
+	})
+
+	// Feed every entry the checkpoint hasn't already settled to the bulk
+	// indexer, so a restart after a crash resumes instead of reindexing
+	// everything.
+	var addErr error
+	for _, entry := range entries {
+		if checkpoint.States[entry.MongoID.Hex()] == entryIndexed {
+			progress.Update(1)
+			continue
+		}
+		if err := bulkIndexer.Add(ctx, buildOSDocument(entry)); err != nil {
+			addErr = err
+			break
+		}
+	}
+
+	if err := bulkIndexer.Close(ctx); err != nil {
+		idx.cli.Warning(fmt.Sprintf("Bulk indexer close: %v", err))
+	}
+
+	cancelProgress()
+	idx.cli.ProgressDone()
+
+	if addErr != nil {
+		return indexed, errors, mongoUpdates, deadLetters, addErr
+	}
+
+	stats := bulkIndexer.Stats()
+	idx.cli.Info(fmt.Sprintf("Bulk indexer: %d added, %d flushed, %d failed, %d retried, %s in / %s out",
+		stats.NumAdded, stats.NumFlushed, stats.NumFailed, stats.NumRetried, formatBytes(stats.BytesIn), formatBytes(stats.BytesOut)))
+
+	return indexed, errors, mongoUpdates, deadLetters, nil
+}
+
+// phase2BulkIndexGeneric feeds entries through idx.searchBackend in simple
+// fixed-size batches, the indexing path used for every non-OpenSearch
+// backend (none of which have BulkIndexer's streaming/retry machinery).
+func (idx *Indexer) phase2BulkIndexGeneric(ctx context.Context, entries []cache.CacheEntry, checkpoint *phase2Checkpoint) (indexed, errors int64, mongoUpdates []mongodb.IDUpdate, deadLetters []deadLetterEntry, err error) {
+	progress := cli.NewProgress(int64(len(entries)))
+	defer idx.cli.ProgressDone()
+
+	batch := make([]cache.CacheEntry, 0, idx.cfg.OpenSearchBulkSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		docs := make([]search.Document, len(batch))
+		for i, entry := range batch {
+			docs[i] = search.DocumentFromOSDocument(buildOSDocument(entry))
+		}
+		ids, bulkErr := idx.searchBackend.BulkIndex(ctx, docs)
+		for _, entry := range batch {
+			mongoID, hexErr := primitive.ObjectIDFromHex(entry.MongoID.Hex())
+			if hexErr != nil {
+				continue
+			}
+			backendID, ok := ids[entry.MongoID.Hex()]
+			if !ok {
+				reason := "backend did not return an id for this document"
+				if bulkErr != nil {
+					reason = bulkErr.Error()
+				}
+				checkpoint.States[entry.MongoID.Hex()] = entryFailed
+				deadLetters = append(deadLetters, deadLetterEntry{
+					MongoID:  entry.MongoID.Hex(),
+					Title:    entry.Title,
+					Error:    reason,
+					FailedAt: time.Now(),
+				})
+				errors++
+				continue
+			}
+			checkpoint.States[entry.MongoID.Hex()] = entryIndexed
+			mongoUpdates = append(mongoUpdates, mongodb.IDUpdate{
+				MongoID:      mongoID,
+				OpenSearchID: backendID,
+				TenantID:     idx.tenantID,
+			})
+			indexed++
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for _, entry := range entries {
+		if checkpoint.States[entry.MongoID.Hex()] == entryIndexed {
+			progress.Update(1)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return indexed, errors, mongoUpdates, deadLetters, ctx.Err()
+		default:
+		}
+		batch = append(batch, entry)
+		if len(batch) >= idx.cfg.OpenSearchBulkSize {
+			if err := flush(); err != nil {
+				return indexed, errors, mongoUpdates, deadLetters, err
+			}
+		}
+		progress.Update(1)
+		idx.cli.Progress(progress)
+	}
+	if err := flush(); err != nil {
+		return indexed, errors, mongoUpdates, deadLetters, err
+	}
+
+	return indexed, errors, mongoUpdates, deadLetters, nil
+}
+
+// buildOSDocument translates a cache entry into the OpenSearch document
+// shape, flattening author name variants onto the top-level document
+// (AuthorNameVariants) as well as keeping them per-author (Authors) for the
+// nested author query.
+func buildOSDocument(entry cache.CacheEntry) opensearch.OSDocument {
+	osAuthors := make([]opensearch.OSAuthor, len(entry.Authors))
+	authorNames := make([]string, len(entry.Authors))
+	allVariants := make([]string, 0)
+
+	for k, a := range entry.Authors {
+		authorNames[k] = a.AuthorName
+		if len(a.AuthorAvailableNames) > 0 {
+			allVariants = append(allVariants, a.AuthorAvailableNames...)
+		}
+
+		position := 0
+		if a.AuthorPosition != "" {
+			fmt.Sscanf(a.AuthorPosition, "%d", &position)
+		}
+
+		osAuthors[k] = opensearch.OSAuthor{
+			AuthorID:           a.AuthorID,
+			AuthorName:         a.AuthorName,
+			AuthorNameVariants: a.AuthorAvailableNames,
+			AuthorPosition:     position,
+			AuthorAffiliation:  a.AuthorAffiliation,
+			AuthorEmail:        a.AuthorEmail,
+			HasMatchedProfile:  a.HasMatchedProfile,
+		}
+	}
+
+	return opensearch.OSDocument{
+		MongoID:            entry.MongoID.Hex(),
+		Title:              entry.Title,
+		Abstract:           entry.Abstract,
+		Authors:            osAuthors,
+		AuthorNames:        authorNames,
+		AuthorNameVariants: allVariants,
+		PublicationYear:    entry.PublicationYear,
+		FieldAssociated:    entry.FieldAssociated,
+		DocumentType:       entry.DocumentType,
+		SubjectArea:        entry.SubjectArea,
+		SubjectAreaCount:   len(entry.SubjectArea),
+		CitationCount:      entry.CitationCount,
+		ReferenceCount:     entry.ReferenceCount,
+		Embedding:          entry.Embedding,
+	}
+}
+
 // RunBothPhases runs Phase 1 and Phase 2 sequentially
 func (idx *Indexer) RunBothPhases(ctx context.Context, limit int, reindexAll bool) error {
 	if err := idx.Phase1FetchAndEmbed(ctx, limit, reindexAll); err != nil {
@@ -622,10 +1128,66 @@ func (idx *Indexer) CacheStatus() {
 	if meta.TotalDocs > 0 {
 		metadata["Total Docs"] = fmt.Sprintf("%d", meta.TotalDocs)
 	}
+	if hr, ok := idx.embedClient.(providerHealthReporter); ok {
+		for k, v := range hr.ProviderHealth() {
+			metadata[k] = v
+		}
+	}
+
+	switch {
+	case idx.openSearch != nil:
+		health := idx.openSearch.LastHealth()
+		metadata["OpenSearch Status"] = health.Status
+		metadata["OpenSearch Nodes"] = fmt.Sprintf("%d", health.NumberOfNodes)
+		metadata["OpenSearch Pending Tasks"] = fmt.Sprintf("%d", health.NumberOfPendingTasks)
+		metadata["OpenSearch Breaker"] = idx.openSearch.BreakerState()
+	case searchBackendName(idx.cfg) == "opensearch":
+		metadata["OpenSearch Status"] = "unavailable"
+	}
 
 	idx.cli.CacheStatus(idx.cache.Exists(), entries, size, metadata)
 }
 
+// SearchBackendHealth reports whether the search backend is ready to serve
+// BulkIndex/Search calls right now, for the `health` command's single-shot
+// readiness check. detail is a short human-readable summary.
+func (idx *Indexer) SearchBackendHealth() (available bool, detail string) {
+	switch {
+	case idx.openSearch != nil:
+		health := idx.openSearch.LastHealth()
+		available = idx.openSearch.Available()
+		return available, fmt.Sprintf("opensearch: status=%s breaker=%s nodes=%d available=%t",
+			health.Status, idx.openSearch.BreakerState(), health.NumberOfNodes, available)
+	case idx.searchBackend != nil:
+		return true, fmt.Sprintf("%s: connected", searchBackendName(idx.cfg))
+	default:
+		return false, fmt.Sprintf("%s: unavailable", searchBackendName(idx.cfg))
+	}
+}
+
+// healthChecker is implemented by embedding providers that expose a
+// /healthz-style handler (currently the circuit breaker state).
+type healthChecker interface {
+	HealthHandler() http.Handler
+}
+
+// providerHealthReporter is implemented by embedding providers that can
+// report a per-backend health summary (currently FailoverProvider), so
+// CacheStatus can surface which configured backends are up.
+type providerHealthReporter interface {
+	ProviderHealth() map[string]string
+}
+
+// EmbeddingHealthHandler returns the embedding provider's /healthz handler,
+// if it has one, so orchestration can probe circuit breaker state.
+func (idx *Indexer) EmbeddingHealthHandler() (http.Handler, bool) {
+	hc, ok := idx.embedClient.(healthChecker)
+	if !ok {
+		return nil, false
+	}
+	return hc.HealthHandler(), true
+}
+
 // ClearCache removes all cache files
 func (idx *Indexer) ClearCache() error {
 	if err := idx.cache.Clear(); err != nil {
@@ -665,32 +1227,218 @@ func (idx *Indexer) ClearMongoIDs(ctx context.Context) error {
 	return nil
 }
 
-// ReindexFull performs a complete reindex
+// reindexDocCountTolerance is how far a newly built generation's doc count
+// may diverge from MongoDB's before ReindexFull refuses to swap the alias
+// over to it (catches a silently truncated Phase 2 run).
+const reindexDocCountTolerance = 0.01 // 1%
+
+// indexGenerationPrefix is the prefix shared by every backing index
+// ReindexFull creates for the configured alias, e.g.
+// "research_documents-v".
+func (idx *Indexer) indexGenerationPrefix() string {
+	return idx.cfg.OpenSearchIndex + "-v"
+}
+
+// ReindexFull performs a zero-downtime reindex. The configured index name
+// (cfg.OpenSearchIndex) is treated as an alias: a new backing index
+// "{alias}-v{unix timestamp}" is created, both phases run against it while
+// the alias keeps serving the old generation, and only once the new
+// generation's doc count matches MongoDB within tolerance is the alias
+// atomically flipped over. The old generation is then deleted. If the
+// alias doesn't exist yet (first run, or a tree from before this alias
+// scheme), there's nothing to keep serving and no parity check is needed.
 func (idx *Indexer) ReindexFull(ctx context.Context) error {
 	idx.cli.StartPhase("Full Reindex")
 
-	idx.cli.Step(1, 5, "Deleting existing index")
-	if err := idx.DeleteIndex(ctx); err != nil {
-		idx.cli.Warning(fmt.Sprintf("Delete failed (may not exist): %v", err))
+	alias := idx.cfg.OpenSearchIndex
+	newIndex := fmt.Sprintf("%s%d", idx.indexGenerationPrefix(), time.Now().Unix())
+
+	idx.cli.Step(1, 6, fmt.Sprintf("Resolving alias %s", alias))
+	oldIndex, oldIsAlias, err := idx.openSearch.ResolveAlias(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("resolve alias: %w", err)
 	}
 
-	idx.cli.Step(2, 5, "Creating new index")
-	if err := idx.CreateIndex(ctx); err != nil {
-		return err
+	idx.cli.Step(2, 6, fmt.Sprintf("Creating new index generation %s", newIndex))
+	if err := idx.openSearch.CreateIndexNamed(ctx, newIndex); err != nil {
+		return fmt.Errorf("create new generation: %w", err)
 	}
 
-	idx.cli.Step(3, 5, "Clearing MongoDB IDs")
+	idx.cli.Step(3, 6, "Clearing MongoDB IDs")
 	if err := idx.ClearMongoIDs(ctx); err != nil {
 		return err
 	}
 
-	idx.cli.Step(4, 5, "Clearing cache")
-	idx.cache.Clear()
+	// Note: Phase1FetchAndEmbed clears the cache itself for a full reindex,
+	// but only after snapshotting the previous generation's entries, so
+	// content-hash dedup can still reuse embeddings for unchanged documents.
+	idx.cli.Step(4, 6, "Running two-phase indexing into new generation")
+	if err := idx.Phase1FetchAndEmbed(ctx, 0, true); err != nil {
+		return fmt.Errorf("phase 1: %w", err)
+	}
+	if err := idx.phase2IndexAndUpdateInto(ctx, newIndex); err != nil {
+		return fmt.Errorf("phase 2: %w", err)
+	}
+
+	idx.cli.Step(5, 6, "Verifying doc count parity against MongoDB")
+	expected, err := idx.mongoDB.CountDocumentsToIndex(ctx, true, idx.tenantID)
+	if err != nil {
+		return fmt.Errorf("count mongo documents: %w", err)
+	}
+	actual, err := idx.openSearch.CountDocs(ctx, newIndex)
+	if err != nil {
+		return fmt.Errorf("count new generation documents: %w", err)
+	}
+	if expected > 0 {
+		diff := float64(expected-actual) / float64(expected)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > reindexDocCountTolerance {
+			return fmt.Errorf("doc count parity check failed: mongo=%d opensearch=%d (%.1f%% off, tolerance %.1f%%); alias %s left untouched, generation %s kept for inspection",
+				expected, actual, diff*100, reindexDocCountTolerance*100, alias, newIndex)
+		}
+	}
+	idx.cli.Success(fmt.Sprintf("Doc counts within tolerance: mongo=%d opensearch=%d", expected, actual))
+
+	idx.cli.Step(6, 6, fmt.Sprintf("Swapping alias %s to %s", alias, newIndex))
+	swapOld := ""
+	if oldIsAlias {
+		swapOld = oldIndex
+	}
+	if err := idx.openSearch.SwapAlias(ctx, alias, newIndex, swapOld); err != nil {
+		return fmt.Errorf("swap alias: %w", err)
+	}
+
+	// If the old "index" was actually a bare index sharing the alias name
+	// (pre-alias tree, or a first run), it can't be removed via alias swap
+	// since it was never aliased — delete it directly so the alias name is
+	// free to be the alias going forward.
+	if oldIndex != "" && !oldIsAlias {
+		if err := idx.openSearch.DeleteIndexNamed(ctx, oldIndex); err != nil {
+			idx.cli.Warning(fmt.Sprintf("Failed to delete pre-alias index %s: %v", oldIndex, err))
+		}
+	} else if oldIsAlias && oldIndex != "" && oldIndex != newIndex {
+		if err := idx.openSearch.DeleteIndexNamed(ctx, oldIndex); err != nil {
+			idx.cli.Warning(fmt.Sprintf("Failed to delete old generation %s: %v", oldIndex, err))
+		}
+	}
 
-	idx.cli.Step(5, 5, "Running two-phase indexing")
 	idx.cli.EndPhase()
+	return nil
+}
+
+// ListIndexVersions lists the backing index names of every generation
+// ReindexFull has left on the cluster for the configured alias, oldest
+// first, so operators can see what RollbackTo accepts.
+func (idx *Indexer) ListIndexVersions(ctx context.Context) ([]string, error) {
+	return idx.openSearch.ListIndexVersions(ctx, idx.indexGenerationPrefix())
+}
+
+// RollbackTo repoints the alias at a prior generation (one of the names
+// returned by ListIndexVersions) without rerunning either phase, so a
+// generation whose new embeddings underperform can be reverted quickly.
+// The generation being rolled back from is left on the cluster rather than
+// deleted, in case the operator wants to roll forward again.
+func (idx *Indexer) RollbackTo(ctx context.Context, version string) error {
+	alias := idx.cfg.OpenSearchIndex
+
+	exists, _, err := idx.openSearch.ResolveAlias(ctx, version)
+	if err != nil {
+		return fmt.Errorf("resolve target generation: %w", err)
+	}
+	if exists == "" {
+		return fmt.Errorf("generation %s not found", version)
+	}
+
+	oldIndex, oldIsAlias, err := idx.openSearch.ResolveAlias(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("resolve current alias: %w", err)
+	}
+	swapOld := ""
+	if oldIsAlias {
+		swapOld = oldIndex
+	}
+
+	if err := idx.openSearch.SwapAlias(ctx, alias, version, swapOld); err != nil {
+		return fmt.Errorf("swap alias: %w", err)
+	}
+	idx.cli.Success(fmt.Sprintf("Rolled back: alias %s now points to %s", alias, version))
+	return nil
+}
+
+// ReindexMapping brings the configured alias onto the current
+// opensearch.MappingVersion without re-embedding anything: it creates the
+// versioned index if it doesn't already exist, copies every existing
+// document across via the OpenSearch _reindex API, and atomically swaps
+// the alias over once the copy finishes. Unlike ReindexFull this never
+// touches MongoDB or the embedding provider, so a mapping-only change
+// (a new field, different BM25 params, a wider k-NN dimension) is a cheap
+// cluster-side operation instead of a full re-embed.
+func (idx *Indexer) ReindexMapping(ctx context.Context) error {
+	idx.cli.StartPhase("Mapping Reindex")
+
+	alias := idx.cfg.OpenSearchIndex
+
+	idx.cli.Step(1, 3, fmt.Sprintf("Resolving alias %s", alias))
+	oldIndex, _, err := idx.openSearch.ResolveAlias(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("resolve alias: %w", err)
+	}
+
+	idx.cli.Step(2, 3, "Ensuring an index exists for the current mapping version")
+	newIndex, created, err := idx.openSearch.EnsureIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("ensure index: %w", err)
+	}
+	if !created && oldIndex == newIndex {
+		idx.cli.Success(fmt.Sprintf("Alias %s already on mapping version %s", alias, newIndex))
+		idx.cli.EndPhase()
+		return nil
+	}
+
+	// oldIndex is the concrete backing index currently serving reads,
+	// whether it's aliased or (pre-alias tree, or a first run) a bare index
+	// sharing the alias name - either way it's where the documents to copy
+	// live.
+	idx.cli.Step(3, 3, fmt.Sprintf("Reindexing %s -> %s and swapping alias", oldIndex, newIndex))
+	if err := idx.openSearch.Reindex(ctx, oldIndex, newIndex); err != nil {
+		return fmt.Errorf("reindex mapping: %w", err)
+	}
+
+	idx.cli.EndPhase()
+	idx.cli.Success(fmt.Sprintf("Alias %s now points to %s", alias, newIndex))
+	return nil
+}
+
+// Search runs a hybrid BM25 + k-NN query against OpenSearch, embedding
+// queryText with this Indexer's embedding provider to produce the k-NN
+// side of the request (see opensearch.Client.HybridSearch). topK <= 0 uses
+// cfg.SearchTopK.
+func (idx *Indexer) Search(ctx context.Context, queryText string, topK int) (*search.Response, error) {
+	if topK <= 0 {
+		topK = idx.cfg.SearchTopK
+	}
+
+	req := search.Request{
+		QueryText:  queryText,
+		TopK:       topK,
+		RRFK:       idx.cfg.SearchRRFK,
+		BM25Weight: idx.cfg.SearchBM25Weight,
+		KNNWeight:  idx.cfg.SearchKNNWeight,
+	}
+
+	if queryText != "" {
+		embeddings, err := idx.embedClient.GetEmbeddings(ctx, []string{queryText})
+		if err != nil {
+			return nil, fmt.Errorf("embed query: %w", err)
+		}
+		if len(embeddings) > 0 {
+			req.QueryEmbedding = embeddings[0]
+		}
+	}
 
-	return idx.RunBothPhases(ctx, 0, true)
+	return idx.searchBackend.Search(ctx, req)
 }
 
 // formatBytes formats bytes in human-readable format
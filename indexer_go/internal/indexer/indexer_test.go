@@ -0,0 +1,22 @@
+package indexer
+
+import "testing"
+
+func TestContentHashIsStableAndContentSensitive(t *testing.T) {
+	a := contentHash("title [SEP] abstract")
+	b := contentHash("title [SEP] abstract")
+	if a != b {
+		t.Fatalf("contentHash not stable across calls: %q != %q", a, b)
+	}
+
+	c := contentHash("title [SEP] a different abstract")
+	if a == c {
+		t.Fatalf("contentHash did not change for different input text")
+	}
+}
+
+func TestContentHashEmptyString(t *testing.T) {
+	if contentHash("") == "" {
+		t.Fatal("contentHash(\"\") returned an empty string")
+	}
+}
@@ -0,0 +1,103 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	phase2CheckpointFileName = "phase2_checkpoint.json"
+	deadLetterFileName       = "dead-letter.jsonl"
+)
+
+// entryState is where a single cache entry stands in Phase 2, so a restart
+// can skip what's already indexed instead of reindexing everything.
+type entryState string
+
+const (
+	entryIndexed entryState = "indexed"
+	entryFailed  entryState = "failed"
+)
+
+// phase2Checkpoint tracks per-entry indexing state across a Phase 2 run.
+// LastOffset is the batch-aligned entry count processed so far, kept mostly
+// for visibility; States is what resuming actually relies on.
+type phase2Checkpoint struct {
+	LastOffset int                   `json:"last_offset"`
+	States     map[string]entryState `json:"states"`
+}
+
+func (idx *Indexer) phase2CheckpointPath() string {
+	return filepath.Join(idx.cfg.CacheDir, phase2CheckpointFileName)
+}
+
+// loadPhase2Checkpoint reads the checkpoint file, returning a fresh empty
+// checkpoint if none has been written yet.
+func (idx *Indexer) loadPhase2Checkpoint() (*phase2Checkpoint, error) {
+	data, err := os.ReadFile(idx.phase2CheckpointPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &phase2Checkpoint{States: make(map[string]entryState)}, nil
+		}
+		return nil, err
+	}
+
+	var cp phase2Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	if cp.States == nil {
+		cp.States = make(map[string]entryState)
+	}
+	return &cp, nil
+}
+
+// savePhase2Checkpoint persists the checkpoint, overwriting any prior one.
+func (idx *Indexer) savePhase2Checkpoint(cp *phase2Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	return os.WriteFile(idx.phase2CheckpointPath(), data, 0644)
+}
+
+// clearPhase2Checkpoint removes the checkpoint file after a fully
+// successful run, so the next run starts clean rather than skipping entries
+// a later cache rebuild may have changed.
+func (idx *Indexer) clearPhase2Checkpoint() {
+	os.Remove(idx.phase2CheckpointPath())
+}
+
+// deadLetterEntry is the on-disk record for a cache entry that could not be
+// indexed after exhausting retries, so operators can inspect and re-drive
+// it without restarting the whole phase.
+type deadLetterEntry struct {
+	MongoID  string    `json:"mongo_id"`
+	Title    string    `json:"title"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// appendDeadLetters appends records to dead-letter.jsonl in the cache dir.
+func (idx *Indexer) appendDeadLetters(entries []deadLetterEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	path := filepath.Join(idx.cfg.CacheDir, deadLetterFileName)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("write dead letter: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// RunAllTenants runs both phases once per tenant in cfg.TenantIDs, each
+// against its own Indexer (its own MongoDB/OpenSearch connections and
+// namespaced cache, per NewForPhase1), so one tenant's failure or slow
+// embedding run doesn't hold up another's. cfg.TenantParallelism caps how
+// many run at once; 0 or 1 runs them serially. If cfg.TenantIDs is empty,
+// this runs a single untenanted pass equivalent to New + RunBothPhases,
+// so callers can use it unconditionally regardless of whether multi-tenancy
+// is configured.
+func RunAllTenants(ctx context.Context, cfg *config.Config, quiet bool, limit int, reindexAll bool) error {
+	tenants := cfg.TenantIDs
+	if len(tenants) == 0 {
+		tenants = []string{""}
+	}
+
+	parallelism := cfg.TenantParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	errs := make([]error, len(tenants))
+
+	for i, tenantID := range tenants {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, tenantID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = runOneTenant(ctx, cfg, quiet, limit, reindexAll, tenantID)
+		}(i, tenantID)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("tenant %q: %w", tenants[i], err)
+		}
+	}
+	return nil
+}
+
+// runOneTenant builds a fresh Indexer scoped to tenantID and runs both
+// phases against it.
+func runOneTenant(ctx context.Context, cfg *config.Config, quiet bool, limit int, reindexAll bool, tenantID string) error {
+	idx, err := New(cfg, quiet, tenantID)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	return idx.RunBothPhases(ctx, limit, reindexAll)
+}
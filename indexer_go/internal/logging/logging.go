@@ -0,0 +1,53 @@
+// Package logging provides the indexer's structured (slog) logger and a
+// context.Context carrier for it, so a single request-scoped logger can be
+// threaded from the CLI entrypoint down into indexer/opensearch/embedding
+// without every function taking a *slog.Logger parameter. This is separate
+// from cli.CLI, which renders interactive, human-facing progress output
+// (Docker-style steps, JSON events) rather than operational log lines.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// ctxKey is unexported so only this package can mint context keys, avoiding
+// collisions with keys set by other packages.
+type ctxKey struct{}
+
+// New builds a slog.Logger for the given format ("json" or "text"; anything
+// else falls back to "text"), writing to w.
+func New(format string, w io.Writer) *slog.Logger {
+	var handler slog.Handler
+	switch format {
+	case "json":
+		handler = slog.NewJSONHandler(w, nil)
+	default:
+		handler = slog.NewTextHandler(w, nil)
+	}
+	return slog.New(handler)
+}
+
+// NewFromFormat builds a slog.Logger for the given format, writing to
+// os.Stderr, so operational log lines don't interleave with cli.CLI's
+// stdout progress output.
+func NewFromFormat(format string) *slog.Logger {
+	return New(format, os.Stderr)
+}
+
+// WithLogger returns a copy of ctx carrying logger, for FromContext to
+// retrieve further down the call stack.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or slog.Default() if none
+// was attached - so callers never need a nil check.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
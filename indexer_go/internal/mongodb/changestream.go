@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent is a normalized MongoDB change stream event, stripped down to
+// what the indexer needs to react to it.
+type ChangeEvent struct {
+	OperationType string    // "insert", "update", "replace", or "delete"
+	DocumentID    string    // Hex-encoded _id of the changed document
+	FullDocument  *Document // Present for insert/update/replace; present for delete only if pre-images are enabled on the collection
+	ResumeToken   bson.Raw  // Persist after processing so a restart can resume from here
+}
+
+// WatchChanges opens a change stream on the source collection and streams
+// normalized events until ctx is canceled or the stream ends. Pass the last
+// persisted ResumeToken to resume after a restart, or nil to start watching
+// from the current moment.
+//
+// Delete events only carry FullDocument (and therefore the document's
+// open_search_id) if the collection has change stream pre-images enabled:
+//
+//	db.runCommand({collMod: "<collection>", changeStreamPreAndPostImages: {enabled: true}})
+//
+// Without that, callers must track mongo_id -> open_search_id themselves
+// (e.g. from earlier insert/update events seen in the same run) to know what
+// to delete from OpenSearch.
+func (c *Client) WatchChanges(ctx context.Context, resumeToken bson.Raw) (<-chan ChangeEvent, error) {
+	opts := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	stream, err := c.collection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("open change stream: %w", err)
+	}
+
+	events := make(chan ChangeEvent, 100)
+
+	go func() {
+		defer close(events)
+		defer stream.Close(ctx)
+
+		for stream.Next(ctx) {
+			var raw struct {
+				OperationType    string    `bson:"operationType"`
+				DocumentKey      bson.Raw  `bson:"documentKey"`
+				FullDocument     *Document `bson:"fullDocument"`
+				FullDocBeforeChg *Document `bson:"fullDocumentBeforeChange"`
+			}
+			if err := stream.Decode(&raw); err != nil {
+				log.Printf("change stream: decode event: %v", err)
+				continue
+			}
+
+			var idField struct {
+				ID primitive.ObjectID `bson:"_id"`
+			}
+			if err := bson.Unmarshal(raw.DocumentKey, &idField); err != nil {
+				log.Printf("change stream: decode documentKey: %v", err)
+				continue
+			}
+
+			fullDoc := raw.FullDocument
+			if fullDoc == nil {
+				fullDoc = raw.FullDocBeforeChg
+			}
+
+			select {
+			case events <- ChangeEvent{
+				OperationType: raw.OperationType,
+				DocumentID:    idField.ID.Hex(),
+				FullDocument:  fullDoc,
+				ResumeToken:   stream.ResumeToken(),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			log.Printf("change stream: %v", err)
+		}
+	}()
+
+	return events, nil
+}
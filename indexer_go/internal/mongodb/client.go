@@ -2,6 +2,8 @@ package mongodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
@@ -11,18 +13,74 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/sudarshan/indexer/internal/config"
+	"github.com/sudarshan/indexer/internal/ratelimit"
 )
 
+// rateLimitedErrorCodes are MongoDB CommandError codes that indicate the
+// cluster is shedding load rather than rejecting the operation outright:
+// 16500 (rate limit exceeded, Atlas free/shared tier), 8000
+// (AtlasError, also used for quota throttling), 50 (MaxTimeMSExpired).
+// A BulkWrite hitting any of these should back off instead of retrying at
+// the same rate.
+var rateLimitedErrorCodes = map[int32]bool{
+	16500: true,
+	8000:  true,
+	50:    true,
+}
+
+func isRateLimitedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if mongo.IsTimeout(err) || mongo.IsNetworkError(err) {
+		return true
+	}
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && rateLimitedErrorCodes[cmdErr.Code] {
+		return true
+	}
+	var bwErr mongo.BulkWriteException
+	if errors.As(err, &bwErr) {
+		for _, we := range bwErr.WriteErrors {
+			if rateLimitedErrorCodes[int32(we.Code)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchedProfile links an author to a resolved profile record (e.g. a
+// faculty/researcher directory entry) elsewhere in the system. A nil
+// MatchedProfile on Author means the author hasn't been matched to one.
+type MatchedProfile struct {
+	ProfileID string `bson:"profile_id"`
+}
+
 // Author represents an author in a research document
 type Author struct {
 	AuthorID          string `bson:"author_id"`
 	AuthorName        string `bson:"author_name"`
 	AuthorAffiliation string `bson:"author_affiliation"`
+
+	// AuthorPosition is the author's ordinal position on the paper (e.g.
+	// "1", "2"), stored as a string since source records are inconsistent
+	// about zero-padding; callers parse it to int where needed.
+	AuthorPosition string `bson:"author_position,omitempty"`
+	// AuthorEmail is the author's contact email, if known.
+	AuthorEmail string `bson:"author_email,omitempty"`
+	// AuthorAvailableNames lists alternate spellings/variants of
+	// AuthorName seen across source records.
+	AuthorAvailableNames []string `bson:"author_available_names,omitempty"`
+	// MatchedProfile is set once this author has been resolved to a
+	// profile record; see MatchedProfile.
+	MatchedProfile *MatchedProfile `bson:"matched_profile,omitempty"`
 }
 
 // Document represents a research document from MongoDB
 type Document struct {
 	ID              primitive.ObjectID `bson:"_id"`
+	DocumentEID     string             `bson:"document_eid,omitempty"`
 	Title           string             `bson:"title"`
 	Abstract        string             `bson:"abstract"`
 	Authors         []Author           `bson:"authors"`
@@ -31,7 +89,12 @@ type Document struct {
 	DocumentType    string             `bson:"document_type"`
 	SubjectArea     []string           `bson:"subject_area"`
 	CitationCount   int                `bson:"citation_count"`
+	ReferenceCount  int                `bson:"reference_count"`
 	OpenSearchID    string             `bson:"open_search_id"`
+
+	// TenantID scopes this document to one tenant in a shared collection.
+	// Empty for single-tenant deployments (see config.Config.TenantIDs).
+	TenantID string `bson:"tenant_id,omitempty"`
 }
 
 // Client wraps MongoDB operations
@@ -39,6 +102,7 @@ type Client struct {
 	client     *mongo.Client
 	collection *mongo.Collection
 	cfg        *config.Config
+	limiter    *ratelimit.Limiter
 }
 
 // NewClient creates a new MongoDB client
@@ -79,34 +143,80 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	count, _ := collection.CountDocuments(ctx, bson.M{})
 	log.Printf("  Total documents in collection: %d", count)
 
+	migrator := NewMigrator(client.Database(dbName), builtinMigrations(cfg.MongoCollection))
+	target := cfg.MigrationTargetVersion
+	if target <= 0 {
+		target = migrator.LatestVersion()
+	}
+	migCtx, migCancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer migCancel()
+	if err := migrator.RunTo(migCtx, target); err != nil {
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("run schema migrations: %w", err)
+	}
+
 	return &Client{
 		client:     client,
 		collection: collection,
 		cfg:        cfg,
+		limiter:    ratelimit.New(cfg.MongoRPSMin, cfg.MongoRPS, cfg.MongoRPSStep, cfg.MongoBurst),
 	}, nil
 }
 
+// RateStats returns the current sustained rate, in-flight operation count,
+// cumulative error count, and whether the limiter has backed off below its
+// ceiling, so callers can surface it (e.g. through cli.Progress) instead of
+// rendering an opaque ETA.
+func (c *Client) RateStats() (rate float64, inflight, errs int64, throttled bool) {
+	return c.limiter.Rate(), c.limiter.Inflight(), c.limiter.Errors(), c.limiter.Throttled()
+}
+
 // Close disconnects from MongoDB
 func (c *Client) Close(ctx context.Context) error {
 	return c.client.Disconnect(ctx)
 }
 
-// CountDocumentsToIndex returns the number of documents that need indexing
-func (c *Client) CountDocumentsToIndex(ctx context.Context, reindexAll bool) (int64, error) {
+// AcquireLease takes the indexer_leases lease so this process can run
+// against the same collection as another indexer replica without both
+// writing at once. See Leaser.Acquire.
+func (c *Client) AcquireLease(ctx context.Context, ttl time.Duration) (*Lease, error) {
+	return NewLeaser(c.collection.Database()).Acquire(ctx, ttl)
+}
+
+// CountDocumentsToIndex returns the number of documents that need indexing.
+// tenantID, if non-empty, scopes the count to that tenant's documents only.
+func (c *Client) CountDocumentsToIndex(ctx context.Context, reindexAll bool, tenantID string) (int64, error) {
 	filter := bson.M{}
 	if !reindexAll {
 		filter["open_search_id"] = bson.M{"$in": []interface{}{nil, ""}}
 	}
+	if tenantID != "" {
+		filter["tenant_id"] = tenantID
+	}
 	return c.collection.CountDocuments(ctx, filter)
 }
 
-// StreamDocuments returns a channel of documents to index
-// Optimized: no per-doc delay, backpressure via channel buffer handles throttling
-func (c *Client) StreamDocuments(ctx context.Context, reindexAll bool, limit int) (<-chan Document, error) {
+// StreamDocuments returns a channel of documents to index. Reads are paced
+// by c.limiter (shared with BulkUpdateOpenSearchIDs) instead of a fixed
+// per-doc delay, so a struggling cluster throttles both reads and writes
+// together; channel buffer backpressure still applies on top of that.
+//
+// lease, if non-nil, is checked before each cursor batch is read; once it's
+// no longer Valid() (e.g. this replica paused long enough for another to
+// take over) the stream logs ErrLeaseExpired and stops instead of
+// continuing to hand out documents this replica may no longer be allowed
+// to index. Pass nil to stream without lease coordination.
+//
+// tenantID, if non-empty, scopes the stream to that tenant's documents only;
+// every streamed Document carries the same tenant_id.
+func (c *Client) StreamDocuments(ctx context.Context, reindexAll bool, limit int, lease *Lease, tenantID string) (<-chan Document, error) {
 	filter := bson.M{}
 	if !reindexAll {
 		filter["open_search_id"] = bson.M{"$in": []interface{}{nil, ""}}
 	}
+	if tenantID != "" {
+		filter["tenant_id"] = tenantID
+	}
 
 	opts := options.Find().
 		SetBatchSize(int32(c.cfg.MongoBatchSize)) // Control cursor batch size
@@ -126,10 +236,21 @@ func (c *Client) StreamDocuments(ctx context.Context, reindexAll bool, limit int
 		defer cursor.Close(ctx)
 
 		for cursor.Next(ctx) {
+			if lease != nil && !lease.Valid() {
+				log.Printf("stream documents: %v", ErrLeaseExpired)
+				return
+			}
+
+			if err := c.limiter.Wait(ctx); err != nil {
+				return
+			}
+
 			var doc Document
 			if err := cursor.Decode(&doc); err != nil {
+				c.limiter.Success() // decode failures aren't cluster load; don't throttle on them
 				continue
 			}
+			c.limiter.Success()
 			select {
 			case docChan <- doc:
 				// Channel backpressure naturally throttles - no artificial delay needed
@@ -146,6 +267,24 @@ func (c *Client) StreamDocuments(ctx context.Context, reindexAll bool, limit int
 type IDUpdate struct {
 	MongoID      primitive.ObjectID
 	OpenSearchID string
+
+	// TenantID, if non-empty, guards the update so it can only ever touch
+	// that tenant's own document (see BulkUpdateOpenSearchIDs).
+	TenantID string
+}
+
+// ClearOpenSearchIDs resets open_search_id to "" on every document in the
+// collection, across all tenants. Used to force a full reindex to re-embed
+// and re-index every document on its next run, since StreamDocuments and
+// CountDocumentsToIndex treat a missing/empty open_search_id as "needs
+// indexing".
+func (c *Client) ClearOpenSearchIDs(ctx context.Context) error {
+	_, err := c.collection.UpdateMany(
+		ctx,
+		bson.M{},
+		bson.M{"$set": bson.M{"open_search_id": ""}},
+	)
+	return err
 }
 
 // UpdateOpenSearchID updates the open_search_id field for a document
@@ -158,29 +297,102 @@ func (c *Client) UpdateOpenSearchID(ctx context.Context, mongoID primitive.Objec
 	return err
 }
 
-// BulkUpdateOpenSearchIDs updates multiple documents' open_search_id fields in a single bulk operation
-// Includes throttling for MongoDB free tier
-func (c *Client) BulkUpdateOpenSearchIDs(ctx context.Context, updates []IDUpdate) error {
+// BulkUpdateOpenSearchIDs updates multiple documents' open_search_id fields
+// in a single bulk operation. Paced by c.limiter; on success the rate grows
+// a step towards its ceiling, on a rate-limit-shaped error (see
+// isRateLimitedError) it's halved and only the WriteModels MongoDB actually
+// rejected are retried, after a jittered exponential backoff, up to
+// cfg.MaxRetries attempts.
+//
+// lease, if non-nil, must still be Valid() when the write is about to go
+// out, otherwise the call returns ErrLeaseExpired without writing. Each
+// update also carries lease.Fence() in a $lt guard on the document's
+// lease_fence field, so even a write that slips past the Valid() check
+// (e.g. the lease expired and was re-acquired mid-call) can never
+// overwrite a value a fresher holder already wrote. Pass a nil lease to
+// skip both checks.
+//
+// Each update's TenantID, if set, is also added to its filter so the write
+// can only ever match that tenant's own document, never another tenant's
+// document that happens to share the same _id across tenants.
+func (c *Client) BulkUpdateOpenSearchIDs(ctx context.Context, updates []IDUpdate, lease *Lease) error {
 	if len(updates) == 0 {
 		return nil
 	}
+	if lease != nil && !lease.Valid() {
+		return ErrLeaseExpired
+	}
 
 	models := make([]mongo.WriteModel, len(updates))
 	for i, u := range updates {
+		filter := bson.M{"_id": u.MongoID}
+		if u.TenantID != "" {
+			filter["tenant_id"] = u.TenantID
+		}
+		set := bson.M{"open_search_id": u.OpenSearchID}
+		if lease != nil {
+			// lease_fence may not exist yet on a document no fenced writer
+			// has touched before; that must pass the guard too.
+			filter["$or"] = []bson.M{
+				{"lease_fence": bson.M{"$exists": false}},
+				{"lease_fence": bson.M{"$lt": lease.Fence()}},
+			}
+			set["lease_fence"] = lease.Fence()
+		}
 		models[i] = mongo.NewUpdateOneModel().
-			SetFilter(bson.M{"_id": u.MongoID}).
-			SetUpdate(bson.M{"$set": bson.M{"open_search_id": u.OpenSearchID}})
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": set})
 	}
 
 	opts := options.BulkWrite().SetOrdered(false) // Unordered for better performance
-	_, err := c.collection.BulkWrite(ctx, models, opts)
 
-	// Throttle between bulk writes for free tier
-	if c.cfg.MongoBulkDelayMs > 0 {
-		time.Sleep(time.Duration(c.cfg.MongoBulkDelayMs) * time.Millisecond)
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		_, err := c.collection.BulkWrite(ctx, models, opts)
+		if err == nil {
+			c.limiter.Success()
+			return nil
+		}
+		if !isRateLimitedError(err) || attempt >= c.cfg.MaxRetries {
+			c.limiter.Failure()
+			return err
+		}
+		c.limiter.Failure()
+
+		models = failedModels(models, err)
+		if len(models) == 0 {
+			return nil
+		}
+
+		delay := ratelimit.Backoff(attempt, time.Duration(c.cfg.RetryDelay)*time.Second, 30*time.Second)
+		log.Printf("bulk update open_search_id: rate-limited (%v), retrying %d model(s) in %v", err, len(models), delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
+}
 
-	return err
+// failedModels narrows models down to just the ones a BulkWrite call
+// rejected, using BulkWriteException.WriteErrors[i].Index. If err isn't a
+// BulkWriteException (e.g. a connection-level error with no per-model
+// detail), the whole batch is assumed to have failed and is returned as-is.
+func failedModels(models []mongo.WriteModel, err error) []mongo.WriteModel {
+	var bwErr mongo.BulkWriteException
+	if !errors.As(err, &bwErr) {
+		return models
+	}
+	retry := make([]mongo.WriteModel, 0, len(bwErr.WriteErrors))
+	for _, we := range bwErr.WriteErrors {
+		if we.Index >= 0 && we.Index < len(models) {
+			retry = append(retry, models[we.Index])
+		}
+	}
+	return retry
 }
 
 func splitDBName(uri string) string {
@@ -0,0 +1,208 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const leaseCollection = "indexer_leases"
+const leaseDocID = "indexer"
+
+// ErrLeaseExpired is returned by StreamDocuments and BulkUpdateOpenSearchIDs
+// when the *Lease passed to them is no longer valid at the moment of a
+// cursor batch or bulk write, so a replica that lost its lease (GC pause,
+// network partition) stops writing instead of colliding with whichever
+// replica holds it now.
+var ErrLeaseExpired = errors.New("mongodb: indexer lease no longer valid")
+
+// leaseDoc is the single document in indexer_leases that whichever indexer
+// replica is currently allowed to write holds. Fence increments on every
+// Acquire, so a write stamped with a stale fence can never land after a
+// newer holder has taken over (see BulkUpdateOpenSearchIDs's $lt guard).
+type leaseDoc struct {
+	ID         string    `bson:"_id"`
+	Holder     string    `bson:"holder"`
+	AcquiredAt time.Time `bson:"acquired_at"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+	Fence      int64     `bson:"fence"`
+}
+
+// Leaser acquires the indexer_leases document so two indexer replicas can
+// run for availability without double-indexing: only the current holder's
+// writes are accepted elsewhere in the package.
+type Leaser struct {
+	db *mongo.Database
+}
+
+// NewLeaser returns a Leaser backed by db.
+func NewLeaser(db *mongo.Database) *Leaser {
+	return &Leaser{db: db}
+}
+
+func (l *Leaser) collection() *mongo.Collection {
+	return l.db.Collection(leaseCollection)
+}
+
+// Acquire atomically takes the lease via findOneAndUpdate, filtering on
+// expires_at < now so only an expired or never-held lease can be taken, and
+// upserting so the very first Acquire creates the document. Fence is bumped
+// on every successful acquire, never reused. The returned Lease runs a
+// background goroutine that refreshes expires_at every ttl/3 - the same
+// cadence the Refresh loop in MinIO's distributed lock client uses to keep
+// a held lock alive - until Release is called or a refresh fails.
+func (l *Leaser) Acquire(ctx context.Context, ttl time.Duration) (*Lease, error) {
+	coll := l.collection()
+	if _, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	}); err != nil {
+		return nil, fmt.Errorf("ensure indexer lease TTL index: %w", err)
+	}
+
+	holder := fmt.Sprintf("pid-%d@%s", os.Getpid(), hostname())
+	now := time.Now()
+	filter := bson.M{"_id": leaseDocID, "expires_at": bson.M{"$lt": now}}
+	update := bson.M{
+		"$set": bson.M{
+			"holder":      holder,
+			"acquired_at": now,
+			"expires_at":  now.Add(ttl),
+		},
+		"$inc": bson.M{"fence": int64(1)},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var doc leaseDoc
+	err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("acquire indexer lease: held by another replica")
+		}
+		return nil, fmt.Errorf("acquire indexer lease: %w", err)
+	}
+
+	refreshCtx, cancel := context.WithCancel(context.Background())
+	lease := &Lease{
+		leaser:  l,
+		holder:  holder,
+		fence:   doc.Fence,
+		ttl:     ttl,
+		expires: doc.ExpiresAt,
+		valid:   true,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go lease.refreshLoop(refreshCtx)
+	return lease, nil
+}
+
+// Lease is a time-bounded, fenced claim on the indexer role returned by
+// Leaser.Acquire. Callers should check Valid() before any write that must
+// not happen after the lease has moved on to another replica.
+type Lease struct {
+	leaser *Leaser
+	holder string
+	fence  int64
+	ttl    time.Duration
+
+	mu      sync.RWMutex
+	expires time.Time
+	valid   bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Fence returns the monotonic token stamped on this lease at Acquire time.
+func (lease *Lease) Fence() int64 {
+	return lease.fence
+}
+
+// Valid reports whether this lease is still held: the refresh goroutine
+// hasn't failed or been stopped, and the last confirmed expiry hasn't
+// passed.
+func (lease *Lease) Valid() bool {
+	lease.mu.RLock()
+	defer lease.mu.RUnlock()
+	return lease.valid && time.Now().Before(lease.expires)
+}
+
+// Release stops the refresh goroutine and drops the lease document if this
+// process still holds it, so the next Acquire doesn't have to wait out the
+// TTL.
+func (lease *Lease) Release() {
+	lease.cancel()
+	<-lease.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": leaseDocID, "holder": lease.holder, "fence": lease.fence}
+	if _, err := lease.leaser.collection().DeleteOne(ctx, filter); err != nil {
+		log.Printf("  Warning: failed to release indexer lease: %v", err)
+	}
+	lease.invalidate()
+}
+
+func (lease *Lease) refreshLoop(ctx context.Context) {
+	interval := lease.ttl / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer close(lease.done)
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := lease.refresh(ctx); err != nil {
+				log.Printf("  Warning: indexer lease lost: %v", err)
+				lease.invalidate()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh extends expires_at, but only for the document that still matches
+// this lease's holder and fence - if another replica has since acquired
+// the lease (e.g. this process paused long enough for the TTL to lapse),
+// MatchedCount is 0 and the lease is invalidated instead of clobbering the
+// new holder's expiry.
+func (lease *Lease) refresh(ctx context.Context) error {
+	newExpiry := time.Now().Add(lease.ttl)
+	filter := bson.M{"_id": leaseDocID, "holder": lease.holder, "fence": lease.fence}
+	update := bson.M{"$set": bson.M{"expires_at": newExpiry}}
+
+	res, err := lease.leaser.collection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("lease fenced out by a newer holder")
+	}
+
+	lease.mu.Lock()
+	lease.expires = newExpiry
+	lease.mu.Unlock()
+	return nil
+}
+
+func (lease *Lease) invalidate() {
+	lease.mu.Lock()
+	lease.valid = false
+	lease.mu.Unlock()
+}
@@ -0,0 +1,52 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLease(ttl time.Duration) *Lease {
+	return &Lease{
+		holder:  "pid-1@test",
+		fence:   7,
+		ttl:     ttl,
+		expires: time.Now().Add(ttl),
+		valid:   true,
+		done:    make(chan struct{}),
+	}
+}
+
+func TestLeaseValidUntilExpiry(t *testing.T) {
+	lease := newTestLease(50 * time.Millisecond)
+	if !lease.Valid() {
+		t.Fatal("freshly acquired lease should be valid")
+	}
+
+	time.Sleep(75 * time.Millisecond)
+	if lease.Valid() {
+		t.Fatal("lease should be invalid once expires_at has passed")
+	}
+}
+
+func TestLeaseInvalidateStopsValid(t *testing.T) {
+	lease := newTestLease(time.Hour)
+	if !lease.Valid() {
+		t.Fatal("lease should start valid")
+	}
+
+	lease.invalidate()
+	if lease.Valid() {
+		t.Fatal("lease should be invalid after invalidate(), even with time remaining")
+	}
+}
+
+func TestLeaseFenceIsStable(t *testing.T) {
+	lease := newTestLease(time.Hour)
+	if lease.Fence() != 7 {
+		t.Fatalf("got Fence()=%d, want 7", lease.Fence())
+	}
+	lease.invalidate()
+	if lease.Fence() != 7 {
+		t.Fatalf("Fence() changed after invalidate(): got %d, want 7", lease.Fence())
+	}
+}
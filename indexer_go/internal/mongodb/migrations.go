@@ -0,0 +1,262 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	schemaMigrationsCollection = "schema_migrations"
+	migrationLockCollection    = "schema_migrations_lock"
+	migrationLockID            = "migrator"
+	migrationLockTTL           = 2 * time.Minute
+	migrationLockPollInterval  = 2 * time.Second
+	migrationLockMaxWait       = 30 * time.Second
+)
+
+// Migration is one versioned, forward-only schema change. Versions must
+// never be renumbered or reused once released: add new behavior as a new,
+// higher version instead of editing an old one, since a given deployment
+// may already have recorded it as applied. Up should be safe to re-run
+// (e.g. CreateIndex, which MongoDB treats as a no-op when an identical
+// index already exists), since a crash between running Up and recording
+// the version can replay it on the next startup.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// schemaMigrationRecord is what's persisted to schema_migrations once a
+// migration's Up has run successfully.
+type schemaMigrationRecord struct {
+	ID        int       `bson:"_id"`
+	Version   int       `bson:"version"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// migrationLock is the single document in schema_migrations_lock held by
+// whichever indexer process is currently running migrations. ExpiresAt
+// bounds how long a process that crashed mid-migration can block others.
+type migrationLock struct {
+	ID        string    `bson:"_id"`
+	Holder    string    `bson:"holder"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// Migrator applies an ordered list of Migrations against db, tracking
+// progress in schema_migrations and serializing concurrent runs (e.g. two
+// indexer processes starting at once) with a lock document.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator for the given migrations, which must
+// already be sorted by Version ascending.
+func NewMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// LatestVersion returns the highest version among the Migrator's
+// migrations, or 0 if it has none.
+func (m *Migrator) LatestVersion() int {
+	latest := 0
+	for _, mig := range m.migrations {
+		if mig.Version > latest {
+			latest = mig.Version
+		}
+	}
+	return latest
+}
+
+// RunTo acquires the migration lock and applies every migration with
+// current < Version <= targetVersion, in order, recording each one in
+// schema_migrations as it completes. It's a no-op if the schema is already
+// at or past targetVersion.
+func (m *Migrator) RunTo(ctx context.Context, targetVersion int) error {
+	if err := m.acquireLock(ctx); err != nil {
+		return err
+	}
+	defer m.releaseLock()
+
+	current, err := m.currentVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("read current schema version: %w", err)
+	}
+
+	session, err := m.db.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("start migration session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+		for _, mig := range m.migrations {
+			if mig.Version <= current || mig.Version > targetVersion {
+				continue
+			}
+
+			log.Printf("  Applying schema migration %d: %s", mig.Version, mig.Name)
+			if err := mig.Up(sc, m.db); err != nil {
+				return fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Name, err)
+			}
+
+			record := schemaMigrationRecord{ID: mig.Version, Version: mig.Version, AppliedAt: time.Now()}
+			if _, err := m.db.Collection(schemaMigrationsCollection).InsertOne(sc, record); err != nil {
+				return fmt.Errorf("record migration %d: %w", mig.Version, err)
+			}
+			current = mig.Version
+		}
+		return nil
+	})
+}
+
+// currentVersion returns the highest version recorded in schema_migrations,
+// or 0 if none has run yet.
+func (m *Migrator) currentVersion(ctx context.Context) (int, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	var rec schemaMigrationRecord
+	err := m.db.Collection(schemaMigrationsCollection).FindOne(ctx, bson.M{}, opts).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return rec.Version, nil
+}
+
+// acquireLock takes the single schema_migrations_lock document via
+// findOneAndUpdate, relying on the _id unique index to turn a concurrent
+// acquire attempt into a duplicate-key error rather than a second caller
+// silently proceeding. ExpiresAt lets a lock survive a crashed holder
+// instead of blocking migrations forever.
+func (m *Migrator) acquireLock(ctx context.Context) error {
+	coll := m.db.Collection(migrationLockCollection)
+	if _, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	}); err != nil {
+		return fmt.Errorf("ensure migration lock TTL index: %w", err)
+	}
+
+	holder := fmt.Sprintf("pid-%d@%s", os.Getpid(), hostname())
+	deadline := time.Now().Add(migrationLockMaxWait)
+
+	for {
+		now := time.Now()
+		filter := bson.M{
+			"_id":        migrationLockID,
+			"expires_at": bson.M{"$lt": now},
+		}
+		update := bson.M{"$set": migrationLock{ID: migrationLockID, Holder: holder, ExpiresAt: now.Add(migrationLockTTL)}}
+
+		err := coll.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetUpsert(true)).Err()
+		switch {
+		case err == nil, errors.Is(err, mongo.ErrNoDocuments):
+			// ErrNoDocuments here just means the upsert inserted a fresh
+			// document (there was no "before" doc to return) - we hold the lock.
+			return nil
+		case mongo.IsDuplicateKeyError(err):
+			// Another process holds a still-live lock; wait for it to expire
+			// or release, then retry.
+		default:
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for schema migration lock held by %s", migrationLockMaxWait, holder)
+		}
+		select {
+		case <-time.After(migrationLockPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// releaseLock drops the lock document so the next process doesn't have to
+// wait out the TTL. Best effort: a failure here just falls back to the TTL.
+func (m *Migrator) releaseLock() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := m.db.Collection(migrationLockCollection)
+	if _, err := coll.DeleteOne(ctx, bson.M{"_id": migrationLockID}); err != nil {
+		log.Printf("  Warning: failed to release migration lock: %v", err)
+	}
+}
+
+func hostname() string {
+	h, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return h
+}
+
+// builtinMigrations are the indexes this module actually depends on for
+// correct, non-scanning operation against collectionName. Keep this list
+// append-only: add a new higher Version rather than editing an existing
+// one.
+func builtinMigrations(collectionName string) []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "sparse index on open_search_id for unindexed-document scans",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				// CountDocumentsToIndex and StreamDocuments both filter on
+				// open_search_id being nil/empty; most documents have it
+				// set once indexed, so a sparse index keeps this small.
+				_, err := db.Collection(collectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "open_search_id", Value: 1}},
+					Options: options.Index().
+						SetName("open_search_id_sparse").
+						SetSparse(true),
+				})
+				return err
+			},
+		},
+		{
+			Version: 2,
+			Name:    "compound index on (field_associated, publication_year)",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(collectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{
+						{Key: "field_associated", Value: 1},
+						{Key: "publication_year", Value: 1},
+					},
+					Options: options.Index().SetName("field_associated_publication_year"),
+				})
+				return err
+			},
+		},
+		{
+			Version: 3,
+			Name:    "unique index on open_search_id",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				// Partial so documents that haven't been indexed yet
+				// (open_search_id unset) don't collide on uniqueness.
+				_, err := db.Collection(collectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "open_search_id", Value: 1}},
+					Options: options.Index().
+						SetName("open_search_id_unique").
+						SetUnique(true).
+						SetPartialFilterExpression(bson.M{
+							"open_search_id": bson.M{"$exists": true, "$gt": ""},
+						}),
+				})
+				return err
+			},
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package opensearch
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/config"
+)
+
+// ErrBackendUnavailable is returned by BulkIndex, CreateIndex, and
+// HybridSearch when the circuit breaker is open, so callers can tell "the
+// cluster is known to be down, don't bother retrying right now" apart from
+// an ordinary transient error.
+var ErrBackendUnavailable = errors.New("opensearch: backend unavailable")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker trips after a run of consecutive connection failures (or
+// immediately when the background health monitor sees a red cluster) and
+// fails fast while open, periodically allowing a single probe request
+// through (half-open) to decide whether to close again. Mirrors
+// embedding.circuitBreaker.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state            breakerState
+	consecutiveFails int
+	consecutiveOK    int
+	openedAt         time.Time
+	failureThreshold int
+	cooldown         time.Duration
+	halfOpenOKNeeded int
+}
+
+func newCircuitBreaker(cfg *config.Config) *circuitBreaker {
+	threshold := cfg.OpenSearchBreakerFailureThreshold
+	if threshold <= 0 {
+		threshold = 3
+	}
+	cooldown := cfg.OpenSearchBreakerCooldown
+	if cooldown <= 0 {
+		cooldown = 30
+	}
+	halfOpenOK := cfg.OpenSearchBreakerHalfOpenSuccess
+	if halfOpenOK <= 0 {
+		halfOpenOK = 2
+	}
+
+	return &circuitBreaker{
+		state:            breakerClosed,
+		failureThreshold: threshold,
+		cooldown:         time.Duration(cooldown) * time.Second,
+		halfOpenOKNeeded: halfOpenOK,
+	}
+}
+
+// Allow reports whether a request should proceed, transitioning open ->
+// half-open once the cooldown has elapsed.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) >= b.cooldown {
+			b.state = breakerHalfOpen
+			b.consecutiveOK = 0
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess registers a successful call and closes the breaker if enough
+// consecutive half-open probes have succeeded.
+func (b *circuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFails = 0
+
+	switch b.state {
+	case breakerHalfOpen:
+		b.consecutiveOK++
+		if b.consecutiveOK >= b.halfOpenOKNeeded {
+			b.state = breakerClosed
+		}
+	case breakerOpen:
+		// Shouldn't happen since Allow() gates requests, but stay defensive.
+		b.state = breakerClosed
+	}
+}
+
+// RecordFailure registers a failed call, tripping the breaker open once the
+// failure threshold is hit (or immediately, if a half-open probe failed).
+func (b *circuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerHalfOpen:
+		b.open()
+		return
+	}
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.failureThreshold {
+		b.open()
+	}
+}
+
+// Trip forces the breaker open immediately, for the health monitor to call
+// when a cluster health check comes back red instead of waiting for
+// BulkIndex to see consecutive failures on its own.
+func (b *circuitBreaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != breakerOpen {
+		b.open()
+	}
+}
+
+// open must be called with mu held.
+func (b *circuitBreaker) open() {
+	b.state = breakerOpen
+	b.openedAt = time.Now()
+}
+
+// String renders the breaker state the way the status command reports it.
+func (b *circuitBreaker) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
@@ -0,0 +1,284 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sudarshan/indexer/internal/ratelimit"
+)
+
+const (
+	defaultFlushBytes     = 5 * 1024 * 1024 // ~5 MB, matched against marshaled document size
+	defaultFlushInterval  = 5 * time.Second
+	defaultBulkWorkers    = 4
+	defaultBulkMaxRetries = 5
+
+	bulkBackoffBase = 200 * time.Millisecond
+	bulkBackoffMax  = 30 * time.Second
+)
+
+// BulkIndexerConfig configures a BulkIndexer. Zero values for
+// FlushBytes/FlushInterval/NumWorkers/MaxRetries fall back to package
+// defaults.
+type BulkIndexerConfig struct {
+	// Index is the backing index name documents are flushed into.
+	Index string
+
+	// FlushBytes is the marshaled-document size at which a worker flushes
+	// its buffer without waiting for FlushInterval.
+	FlushBytes int
+	// FlushInterval is the longest a worker lets documents sit unflushed.
+	FlushInterval time.Duration
+	// NumWorkers is how many goroutines batch and flush concurrently.
+	NumWorkers int
+	// MaxRetries caps retry attempts for items OpenSearch reports as
+	// retryable (429 / es_rejected_execution_exception) before giving up
+	// on them.
+	MaxRetries int
+
+	// OnSuccess, if set, is called once per document OpenSearch
+	// successfully indexed, with the _id it was assigned. Called
+	// concurrently from any worker goroutine.
+	OnSuccess func(doc OSDocument, openSearchID string)
+	// OnFailure, if set, is called once per document that failed
+	// permanently: a non-retryable error, or a retryable one that was still
+	// failing after MaxRetries. res is nil when the whole flush request
+	// failed before per-item results were available, in which case err is
+	// set instead. Called concurrently from any worker goroutine.
+	OnFailure func(doc OSDocument, res *BulkItemResult, err error)
+}
+
+// BulkIndexerStats are the aggregate counters tracked across a
+// BulkIndexer's lifetime. Safe to read via Stats() while the indexer is
+// still running.
+type BulkIndexerStats struct {
+	NumAdded   int64
+	NumFlushed int64
+	NumFailed  int64
+	NumRetried int64
+	BytesIn    int64
+	BytesOut   int64
+}
+
+// BulkIndexer is a long-lived, concurrent bulk-indexing helper modeled on
+// the elastic/opensearch-go bulk indexer helpers: callers Add documents as
+// they become available, and NumWorkers background goroutines batch and
+// flush them once a batch reaches FlushBytes or FlushInterval elapses, so
+// indexing doesn't block on a per-batch round trip and a single
+// non-retryable document can't fail the batch around it.
+type BulkIndexer struct {
+	client *Client
+	cfg    BulkIndexerConfig
+
+	items chan OSDocument
+	wg    sync.WaitGroup
+
+	// ctx is the context flush uses for its BulkIndexDetailedInto calls.
+	// It's context.Background() until Close(ctx) stores the caller's ctx,
+	// so a canceled shutdown context bounds the final drain instead of
+	// flush retrying and backing off indefinitely after Close was asked
+	// to return.
+	ctx atomic.Value // context.Context
+
+	stats BulkIndexerStats // fields accessed only via atomic ops
+}
+
+// NewBulkIndexer starts cfg.NumWorkers background workers against c, ready
+// to accept Add calls. Callers must Close it to flush any buffered
+// documents and release the workers.
+func (c *Client) NewBulkIndexer(cfg BulkIndexerConfig) *BulkIndexer {
+	if cfg.FlushBytes <= 0 {
+		cfg.FlushBytes = defaultFlushBytes
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	if cfg.NumWorkers <= 0 {
+		cfg.NumWorkers = defaultBulkWorkers
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultBulkMaxRetries
+	}
+
+	bi := &BulkIndexer{
+		client: c,
+		cfg:    cfg,
+		items:  make(chan OSDocument, cfg.NumWorkers*2),
+	}
+	bi.ctx.Store(context.Background())
+
+	bi.wg.Add(cfg.NumWorkers)
+	for i := 0; i < cfg.NumWorkers; i++ {
+		go bi.worker()
+	}
+	return bi
+}
+
+// Add enqueues doc for indexing, blocking if every worker's buffer is
+// backed up. Returns ctx.Err() if ctx is canceled first.
+func (bi *BulkIndexer) Add(ctx context.Context, doc OSDocument) error {
+	select {
+	case bi.items <- doc:
+		docBytes, _ := json.Marshal(doc)
+		atomic.AddInt64(&bi.stats.NumAdded, 1)
+		atomic.AddInt64(&bi.stats.BytesIn, int64(len(docBytes)))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new documents and flushes every worker's remaining
+// buffer. It always waits for every worker to finish before returning -
+// even once ctx is canceled - so OnSuccess/OnFailure are never still
+// running against caller state after Close returns; ctx only bounds how
+// long the final flush retries before giving up, via the deadline it
+// hands to flush's BulkIndexDetailedInto calls.
+func (bi *BulkIndexer) Close(ctx context.Context) error {
+	bi.ctx.Store(ctx)
+	close(bi.items)
+	done := make(chan struct{})
+	go func() {
+		bi.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the aggregate counters.
+func (bi *BulkIndexer) Stats() BulkIndexerStats {
+	return BulkIndexerStats{
+		NumAdded:   atomic.LoadInt64(&bi.stats.NumAdded),
+		NumFlushed: atomic.LoadInt64(&bi.stats.NumFlushed),
+		NumFailed:  atomic.LoadInt64(&bi.stats.NumFailed),
+		NumRetried: atomic.LoadInt64(&bi.stats.NumRetried),
+		BytesIn:    atomic.LoadInt64(&bi.stats.BytesIn),
+		BytesOut:   atomic.LoadInt64(&bi.stats.BytesOut),
+	}
+}
+
+// worker pulls documents off the shared items channel, batching them until
+// FlushBytes is reached or FlushInterval elapses since the batch's first
+// document, then flushes. It exits once items is closed and any final
+// batch is flushed.
+func (bi *BulkIndexer) worker() {
+	defer bi.wg.Done()
+
+	var batch []OSDocument
+	var batchBytes int
+	timer := time.NewTimer(bi.cfg.FlushInterval)
+	defer timer.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		bi.flush(batch)
+		batch = nil
+		batchBytes = 0
+	}
+
+	for {
+		select {
+		case doc, ok := <-bi.items:
+			if !ok {
+				flush()
+				return
+			}
+			if len(batch) == 0 {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(bi.cfg.FlushInterval)
+			}
+			docBytes, _ := json.Marshal(doc)
+			batch = append(batch, doc)
+			batchBytes += len(docBytes)
+			if batchBytes >= bi.cfg.FlushBytes {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+			timer.Reset(bi.cfg.FlushInterval)
+		}
+	}
+}
+
+// flush sends one batch via BulkIndexDetailedInto, retrying only the items
+// OpenSearch reports as retryable with jittered exponential backoff, and
+// reports whatever's still failing after MaxRetries (or failed with a
+// non-retryable error) to OnFailure. Uses whatever context Close (or
+// NewBulkIndexer's initial background context) last stored in bi.ctx, so a
+// canceled shutdown deadline bounds both the request and the backoff wait
+// instead of retrying indefinitely after the caller gave up waiting.
+func (bi *BulkIndexer) flush(docs []OSDocument) {
+	var requestBytes int64
+	for _, doc := range docs {
+		b, _ := json.Marshal(doc)
+		requestBytes += int64(len(b))
+	}
+	atomic.AddInt64(&bi.stats.BytesOut, requestBytes)
+
+	ctx := bi.ctx.Load().(context.Context)
+	toSend := docs
+	for attempt := 0; attempt < bi.cfg.MaxRetries && len(toSend) > 0; attempt++ {
+		results, err := bi.client.BulkIndexDetailedInto(ctx, bi.cfg.Index, toSend)
+		atomic.AddInt64(&bi.stats.NumFlushed, 1)
+		if err != nil {
+			for _, doc := range toSend {
+				bi.fail(doc, nil, err)
+			}
+			return
+		}
+
+		var retry []OSDocument
+		for i, r := range results {
+			switch {
+			case r.Success:
+				if bi.cfg.OnSuccess != nil {
+					bi.cfg.OnSuccess(toSend[i], r.OpenSearchID)
+				}
+			case r.Retryable():
+				retry = append(retry, toSend[i])
+			default:
+				res := r
+				bi.fail(toSend[i], &res, nil)
+			}
+		}
+
+		toSend = retry
+		if len(toSend) > 0 {
+			atomic.AddInt64(&bi.stats.NumRetried, int64(len(toSend)))
+			if attempt < bi.cfg.MaxRetries-1 {
+				select {
+				case <-time.After(ratelimit.Backoff(attempt, bulkBackoffBase, bulkBackoffMax)):
+				case <-ctx.Done():
+				}
+			}
+		}
+	}
+
+	for _, doc := range toSend {
+		bi.fail(doc, nil, fmt.Errorf("retries exhausted"))
+	}
+}
+
+func (bi *BulkIndexer) fail(doc OSDocument, res *BulkItemResult, err error) {
+	atomic.AddInt64(&bi.stats.NumFailed, 1)
+	if bi.cfg.OnFailure != nil {
+		bi.cfg.OnFailure(doc, res, err)
+	}
+}
@@ -7,18 +7,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 
 	"github.com/sudarshan/indexer/internal/config"
+	"github.com/sudarshan/indexer/internal/logging"
 )
 
 // Client wraps OpenSearch operations
 type Client struct {
 	client *opensearch.Client
 	cfg    *config.Config
+
+	// Availability tracking (see health.go): healthMu guards lastHealth,
+	// which the background monitor started by NewClient keeps current.
+	// breaker fails BulkIndex/CreateIndex/HybridSearch fast once the
+	// cluster looks down, instead of letting every caller hammer it.
+	healthMu   sync.RWMutex
+	lastHealth ClusterHealth
+	breaker    *circuitBreaker
+	stopHealth chan struct{}
 }
 
 // OSAuthor represents a nested author document in OpenSearch
@@ -79,17 +92,51 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		return nil, fmt.Errorf("opensearch error: %s", res.String())
 	}
 
-	return &Client{
-		client: client,
-		cfg:    cfg,
-	}, nil
+	c := &Client{
+		client:     client,
+		cfg:        cfg,
+		breaker:    newCircuitBreaker(cfg),
+		stopHealth: make(chan struct{}),
+	}
+	c.startHealthMonitor(time.Duration(cfg.OpenSearchHealthCheckInterval) * time.Second)
+
+	return c, nil
 }
 
-// BulkIndex indexes multiple documents at once
-// Returns map of mongo_id -> opensearch_id for successful indexes
-func (c *Client) BulkIndex(ctx context.Context, docs []OSDocument) (map[string]string, error) {
+// BulkItemResult is the per-document outcome of a BulkIndexDetailed call.
+type BulkItemResult struct {
+	MongoID      string
+	OpenSearchID string
+	Success      bool
+	Status       int
+	ErrorType    string
+	ErrorReason  string
+}
+
+// Retryable reports whether the failure looks transient (OpenSearch
+// rejected the write because a thread pool was saturated) rather than a
+// permanent mapping/validation error, so callers know whether retrying the
+// same document is worth it.
+func (r BulkItemResult) Retryable() bool {
+	if r.Success {
+		return false
+	}
+	return r.Status == http.StatusTooManyRequests || r.ErrorType == "es_rejected_execution_exception"
+}
+
+// BulkIndexDetailed indexes multiple documents and returns a per-document
+// result, so callers can retry only the documents that failed instead of
+// the whole batch.
+func (c *Client) BulkIndexDetailed(ctx context.Context, docs []OSDocument) ([]BulkItemResult, error) {
+	return c.BulkIndexDetailedInto(ctx, c.cfg.OpenSearchIndex, docs)
+}
+
+// BulkIndexDetailedInto is BulkIndexDetailed against an explicit index name
+// instead of cfg.OpenSearchIndex, so ReindexFull can write into a new
+// generation's backing index before it's aliased into place.
+func (c *Client) BulkIndexDetailedInto(ctx context.Context, index string, docs []OSDocument) ([]BulkItemResult, error) {
 	if len(docs) == 0 {
-		return map[string]string{}, nil
+		return nil, nil
 	}
 
 	var buf bytes.Buffer
@@ -97,7 +144,7 @@ func (c *Client) BulkIndex(ctx context.Context, docs []OSDocument) (map[string]s
 		// Action line
 		action := map[string]interface{}{
 			"index": map[string]interface{}{
-				"_index": c.cfg.OpenSearchIndex,
+				"_index": index,
 			},
 		}
 		actionBytes, _ := json.Marshal(action)
@@ -125,13 +172,17 @@ func (c *Client) BulkIndex(ctx context.Context, docs []OSDocument) (map[string]s
 		return nil, fmt.Errorf("bulk error: %s", res.String())
 	}
 
-	// Parse response to get IDs
+	// Parse response to get IDs and, for failures, the error reason
 	var bulkRes struct {
 		Items []struct {
 			Index struct {
 				ID     string `json:"_id"`
 				Result string `json:"result"`
 				Status int    `json:"status"`
+				Error  *struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				} `json:"error"`
 			} `json:"index"`
 		} `json:"items"`
 	}
@@ -139,37 +190,162 @@ func (c *Client) BulkIndex(ctx context.Context, docs []OSDocument) (map[string]s
 		return nil, fmt.Errorf("decode bulk response: %w", err)
 	}
 
-	// Build mongo_id -> os_id map
-	idMap := make(map[string]string)
+	results := make([]BulkItemResult, len(docs))
 	for i, item := range bulkRes.Items {
+		results[i] = BulkItemResult{
+			MongoID: docs[i].MongoID,
+			Status:  item.Index.Status,
+		}
 		if item.Index.Status >= 200 && item.Index.Status < 300 {
-			idMap[docs[i].MongoID] = item.Index.ID
+			results[i].Success = true
+			results[i].OpenSearchID = item.Index.ID
+		} else if item.Index.Error != nil {
+			results[i].ErrorType = item.Index.Error.Type
+			results[i].ErrorReason = item.Index.Error.Reason
 		}
 	}
 
+	return results, nil
+}
+
+// BulkIndex indexes multiple documents at once.
+// Returns map of mongo_id -> opensearch_id for successful indexes. Callers
+// that need to retry individual failures should use BulkIndexDetailed.
+func (c *Client) BulkIndex(ctx context.Context, docs []OSDocument) (map[string]string, error) {
+	if !c.breaker.Allow() {
+		return nil, ErrBackendUnavailable
+	}
+
+	results, err := c.BulkIndexDetailed(ctx, docs)
+	if err != nil {
+		c.breaker.RecordFailure()
+		return nil, err
+	}
+	c.breaker.RecordSuccess()
+
+	idMap := make(map[string]string)
+	for _, r := range results {
+		if r.Success {
+			idMap[r.MongoID] = r.OpenSearchID
+		}
+	}
 	return idMap, nil
 }
 
-// CreateIndex creates the OpenSearch index with enhanced mappings
+// BulkDelete removes documents from the index by their OpenSearch _id,
+// for mapping change-stream delete events onto bulk delete actions instead
+// of a slower delete-by-query against mongo_id.
+func (c *Client) BulkDelete(ctx context.Context, osIDs []string) error {
+	if len(osIDs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, id := range osIDs {
+		action := map[string]interface{}{
+			"delete": map[string]interface{}{
+				"_index": c.cfg.OpenSearchIndex,
+				"_id":    id,
+			},
+		}
+		actionBytes, _ := json.Marshal(action)
+		buf.Write(actionBytes)
+		buf.WriteByte('\n')
+	}
+
+	req := opensearchapi.BulkRequest{
+		Body:    strings.NewReader(buf.String()),
+		Refresh: "true",
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("bulk delete request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("bulk delete error: %s", res.String())
+	}
+
+	return nil
+}
+
+// DeleteByMongoIDs removes documents by mongo_id via delete-by-query, for
+// callers (e.g. the search.Backend interface) that only have the MongoID
+// and not the OpenSearch _id BulkDelete needs.
+func (c *Client) DeleteByMongoIDs(ctx context.Context, mongoIDs []string) error {
+	if len(mongoIDs) == 0 {
+		return nil
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"mongo_id": mongoIDs,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encode delete-by-query body: %w", err)
+	}
+
+	req := opensearchapi.DeleteByQueryRequest{
+		Index: []string{c.cfg.OpenSearchIndex},
+		Body:  bytes.NewReader(query),
+	}
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("delete by query: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("delete by query error: %s", res.String())
+	}
+
+	return nil
+}
+
+// CreateIndex creates the OpenSearch index (or, once ReindexFull has run,
+// the alias's current backing index) with enhanced mappings.
+func (c *Client) CreateIndex(ctx context.Context) error {
+	return c.CreateIndexNamed(ctx, c.cfg.OpenSearchIndex)
+}
+
+// CreateIndexNamed creates a single backing index under an explicit name
+// with the enhanced mappings, without touching any alias. ReindexFull uses
+// this to build a new generation (e.g. "research_documents-v1700000000")
+// before swapping the alias over to it.
+//
 // Features:
 // - Custom BM25 parameters (k1=1.8, b=0.6) tuned for academic text
 // - Shingle analyzer for phrase matching
 // - Nested author mapping with name variants
 // - Subject area count for interdisciplinary filtering
-func (c *Client) CreateIndex(ctx context.Context) error {
+func (c *Client) CreateIndexNamed(ctx context.Context, name string) error {
+	if !c.breaker.Allow() {
+		return ErrBackendUnavailable
+	}
+
 	// Check if index exists
-	res, err := c.client.Indices.Exists([]string{c.cfg.OpenSearchIndex})
+	res, err := c.client.Indices.Exists([]string{name})
 	if err != nil {
 		return fmt.Errorf("check index exists: %w", err)
 	}
 	res.Body.Close()
 
 	if res.StatusCode == 200 {
-		fmt.Printf("Index %s already exists\n", c.cfg.OpenSearchIndex)
+		logging.FromContext(ctx).Info("index already exists", "index", name)
 		return nil
 	}
 
-	mapping := `{
+	dim := c.cfg.EmbeddingDim
+	if dim <= 0 {
+		dim = 768
+	}
+
+	mapping := fmt.Sprintf(`{
 		"settings": {
 			"index": {
 				"knn": true,
@@ -329,7 +505,7 @@ func (c *Client) CreateIndex(ctx context.Context) error {
 				"reference_count": {"type": "integer"},
 				"embedding": {
 					"type": "knn_vector",
-					"dimension": 768,
+					"dimension": %d,
 					"method": {
 						"name": "hnsw",
 						"space_type": "cosinesimil",
@@ -342,10 +518,10 @@ func (c *Client) CreateIndex(ctx context.Context) error {
 				}
 			}
 		}
-	}`
+	}`, dim)
 
 	createReq := opensearchapi.IndicesCreateRequest{
-		Index: c.cfg.OpenSearchIndex,
+		Index: name,
 		Body:  strings.NewReader(mapping),
 	}
 
@@ -359,13 +535,172 @@ func (c *Client) CreateIndex(ctx context.Context) error {
 		return fmt.Errorf("create index error: %s", res.String())
 	}
 
-	fmt.Printf("Created index %s with enhanced mapping\n", c.cfg.OpenSearchIndex)
+	logging.FromContext(ctx).Info("created index", "index", name)
+	return nil
+}
+
+// MappingVersion is bumped whenever CreateIndexNamed's mapping changes
+// (new fields, analyzer tweaks, a different k-NN dimension). EnsureIndex
+// and Reindex use it to tell whether the alias is already backed by an
+// index built under the current mapping, without diffing live OpenSearch
+// mappings.
+const MappingVersion = 1
+
+// mappingIndexName is the backing index name for cfg.OpenSearchIndex's
+// alias at the current MappingVersion, e.g. "research_documents_v1".
+func (c *Client) mappingIndexName() string {
+	return fmt.Sprintf("%s_v%d", c.cfg.OpenSearchIndex, MappingVersion)
+}
+
+// EnsureIndex makes sure a backing index exists for the current
+// MappingVersion, creating one (with the current mapping) if it doesn't
+// exist yet. It never touches the alias - callers that need the alias
+// moved over, and the previous generation's documents copied across,
+// should follow up with Reindex. created is false when the current-version
+// index already existed, so the caller can skip reindexing entirely.
+func (c *Client) EnsureIndex(ctx context.Context) (indexName string, created bool, err error) {
+	indexName = c.mappingIndexName()
+
+	res, err := c.client.Indices.Exists([]string{indexName})
+	if err != nil {
+		return "", false, fmt.Errorf("check index exists: %w", err)
+	}
+	res.Body.Close()
+	if res.StatusCode == 200 {
+		return indexName, false, nil
+	}
+
+	if err := c.CreateIndexNamed(ctx, indexName); err != nil {
+		return "", false, fmt.Errorf("create mapping version %s: %w", indexName, err)
+	}
+	return indexName, true, nil
+}
+
+// reindexTaskPollInterval is how often Reindex polls the OpenSearch task
+// API while a _reindex is running.
+const reindexTaskPollInterval = 2 * time.Second
+
+// Reindex copies every document from fromIndex into toIndex via the
+// OpenSearch _reindex API, started with wait_for_completion=false and
+// polled via the task API so a large mapping migration doesn't tie up an
+// HTTP connection for its whole duration. Once the copy finishes it
+// atomically swaps the configured alias from fromIndex to toIndex in a
+// single _aliases call, then deletes fromIndex now that the alias no
+// longer serves it. fromIndex may be empty if the alias doesn't exist yet,
+// in which case nothing is copied or deleted - the alias is simply pointed
+// at toIndex.
+func (c *Client) Reindex(ctx context.Context, fromIndex, toIndex string) error {
+	if fromIndex != "" && fromIndex != toIndex {
+		taskID, err := c.startReindexTask(ctx, fromIndex, toIndex)
+		if err != nil {
+			return fmt.Errorf("start reindex task: %w", err)
+		}
+		if err := c.waitForReindexTask(ctx, taskID); err != nil {
+			return fmt.Errorf("reindex task %s: %w", taskID, err)
+		}
+	}
+
+	alias := c.cfg.OpenSearchIndex
+	if err := c.SwapAlias(ctx, alias, toIndex, fromIndex); err != nil {
+		return fmt.Errorf("swap alias: %w", err)
+	}
+
+	if fromIndex != "" && fromIndex != toIndex {
+		if err := c.DeleteIndexNamed(ctx, fromIndex); err != nil {
+			return fmt.Errorf("delete old mapping version %s: %w", fromIndex, err)
+		}
+	}
 	return nil
 }
 
+// startReindexTask kicks off an async _reindex from fromIndex to toIndex
+// and returns the OpenSearch task ID tracking it.
+func (c *Client) startReindexTask(ctx context.Context, fromIndex, toIndex string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"source": map[string]string{"index": fromIndex},
+		"dest":   map[string]string{"index": toIndex},
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode reindex body: %w", err)
+	}
+
+	waitForCompletion := false
+	reindexReq := opensearchapi.ReindexRequest{
+		Body:              bytes.NewReader(body),
+		WaitForCompletion: &waitForCompletion,
+	}
+	res, err := reindexReq.Do(ctx, c.client)
+	if err != nil {
+		return "", fmt.Errorf("start reindex: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("start reindex error: %s", res.String())
+	}
+
+	var task struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return "", fmt.Errorf("decode reindex response: %w", err)
+	}
+	return task.Task, nil
+}
+
+// waitForReindexTask polls the OpenSearch task API for taskID every
+// reindexTaskPollInterval until it reports completed, returning any
+// failure the task recorded.
+func (c *Client) waitForReindexTask(ctx context.Context, taskID string) error {
+	for {
+		getReq := opensearchapi.TasksGetRequest{TaskID: taskID}
+		res, err := getReq.Do(ctx, c.client)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		var status struct {
+			Completed bool `json:"completed"`
+			Error     *struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+			Response struct {
+				Failures []json.RawMessage `json:"failures"`
+			} `json:"response"`
+		}
+		decodeErr := json.NewDecoder(res.Body).Decode(&status)
+		res.Body.Close()
+		if decodeErr != nil {
+			return fmt.Errorf("decode task status: %w", decodeErr)
+		}
+
+		if status.Completed {
+			if status.Error != nil {
+				return fmt.Errorf("task failed: %s", status.Error.Reason)
+			}
+			if len(status.Response.Failures) > 0 {
+				return fmt.Errorf("reindex completed with %d document failures", len(status.Response.Failures))
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reindexTaskPollInterval):
+		}
+	}
+}
+
 // DeleteIndex deletes the OpenSearch index (for reindexing)
 func (c *Client) DeleteIndex(ctx context.Context) error {
-	res, err := c.client.Indices.Delete([]string{c.cfg.OpenSearchIndex})
+	return c.DeleteIndexNamed(ctx, c.cfg.OpenSearchIndex)
+}
+
+// DeleteIndexNamed deletes a single backing index by name, e.g. a prior
+// generation left behind after ReindexFull swaps the alias away from it.
+func (c *Client) DeleteIndexNamed(ctx context.Context, name string) error {
+	res, err := c.client.Indices.Delete([]string{name})
 	if err != nil {
 		return fmt.Errorf("delete index: %w", err)
 	}
@@ -375,11 +710,150 @@ func (c *Client) DeleteIndex(ctx context.Context) error {
 		return fmt.Errorf("delete index error: %s", res.String())
 	}
 
-	fmt.Printf("Deleted index %s\n", c.cfg.OpenSearchIndex)
+	logging.FromContext(ctx).Info("deleted index", "index", name)
+	return nil
+}
+
+// CountDocs returns the document count of a single concrete index, used to
+// check doc-count parity between a freshly built generation and MongoDB
+// before ReindexFull will flip the alias over to it.
+func (c *Client) CountDocs(ctx context.Context, index string) (int64, error) {
+	countReq := opensearchapi.CountRequest{
+		Index: []string{index},
+	}
+
+	res, err := countReq.Do(ctx, c.client)
+	if err != nil {
+		return 0, fmt.Errorf("count request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("count error: %s", res.String())
+	}
+
+	var countRes struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&countRes); err != nil {
+		return 0, fmt.Errorf("decode count response: %w", err)
+	}
+	return countRes.Count, nil
+}
+
+// ResolveAlias reports what the configured index name currently points at.
+// If it's an alias, backingIndex is the (first) concrete index it resolves
+// to and isAlias is true. If it's already a concrete index (the state
+// before the first ReindexFull run with alias swapping), backingIndex is
+// the same name and isAlias is false. If nothing exists yet, backingIndex
+// is "".
+func (c *Client) ResolveAlias(ctx context.Context, alias string) (backingIndex string, isAlias bool, err error) {
+	aliasReq := opensearchapi.IndicesGetAliasRequest{
+		Index: []string{alias},
+	}
+	res, err := aliasReq.Do(ctx, c.client)
+	if err != nil {
+		return "", false, fmt.Errorf("get alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 200 {
+		var aliasRes map[string]json.RawMessage
+		if err := json.NewDecoder(res.Body).Decode(&aliasRes); err != nil {
+			return "", false, fmt.Errorf("decode alias response: %w", err)
+		}
+		for backing := range aliasRes {
+			return backing, true, nil
+		}
+	}
+
+	// Not an alias: fall back to checking whether it's a concrete index.
+	existsRes, err := c.client.Indices.Exists([]string{alias})
+	if err != nil {
+		return "", false, fmt.Errorf("check index exists: %w", err)
+	}
+	existsRes.Body.Close()
+	if existsRes.StatusCode == 200 {
+		return alias, false, nil
+	}
+
+	return "", false, nil
+}
+
+// SwapAlias atomically repoints alias from oldIndex to newIndex via a
+// single _aliases call, so search traffic never sees the alias unresolved.
+// oldIndex may be empty if the alias doesn't exist yet.
+func (c *Client) SwapAlias(ctx context.Context, alias, newIndex, oldIndex string) error {
+	actions := []map[string]interface{}{
+		{"add": map[string]string{"index": newIndex, "alias": alias}},
+	}
+	if oldIndex != "" && oldIndex != newIndex {
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]string{"index": oldIndex, "alias": alias},
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("encode alias actions: %w", err)
+	}
+
+	updateReq := opensearchapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(body),
+	}
+	res, err := updateReq.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("update aliases: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("update aliases error: %s", res.String())
+	}
+
+	logging.FromContext(ctx).Info("alias repointed", "alias", alias, "index", newIndex)
 	return nil
 }
 
-// Close closes the client (no-op for opensearch-go but kept for interface consistency)
+// ListIndexVersions lists the backing indices for a generation prefix (e.g.
+// "research_documents-v"), oldest first, so operators can see what
+// RollbackTo accepts.
+func (c *Client) ListIndexVersions(ctx context.Context, prefix string) ([]string, error) {
+	catReq := opensearchapi.CatIndicesRequest{
+		Index:  []string{prefix + "*"},
+		Format: "json",
+	}
+	res, err := catReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("cat indices: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cat indices error: %s", res.String())
+	}
+
+	var rows []struct {
+		Index string `json:"index"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("decode cat indices response: %w", err)
+	}
+
+	versions := make([]string, len(rows))
+	for i, row := range rows {
+		versions[i] = row.Index
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// Close stops the background health monitor (no-op otherwise, since
+// opensearch-go itself needs no teardown).
 func (c *Client) Close() error {
+	close(c.stopHealth)
 	return nil
 }
@@ -0,0 +1,139 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+// ClusterHealth is the subset of the Cluster Health API response the status
+// command and the availability gate care about.
+type ClusterHealth struct {
+	Status               string `json:"status"` // "green", "yellow", or "red"
+	NumberOfNodes        int    `json:"number_of_nodes"`
+	NumberOfPendingTasks int    `json:"number_of_pending_tasks"`
+}
+
+// defaultHealthCheckInterval is used when cfg.OpenSearchHealthCheckInterval
+// is unset or non-positive.
+const defaultHealthCheckInterval = 10 * time.Second
+
+// startHealthMonitor launches the background goroutine that keeps
+// lastHealth (and, transitively, Available) up to date, stopping when
+// stopHealth is closed by Close.
+func (c *Client) startHealthMonitor(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	c.checkHealth(context.Background())
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.checkHealth(context.Background())
+			case <-c.stopHealth:
+				return
+			}
+		}
+	}()
+}
+
+// checkHealth pings the cluster via Cluster Health and records the result,
+// tripping the circuit breaker immediately on a red cluster or an
+// unreachable one instead of waiting for BulkIndex to see consecutive
+// failures on its own.
+func (c *Client) checkHealth(ctx context.Context) {
+	health, err := c.fetchClusterHealth(ctx)
+
+	c.healthMu.Lock()
+	if err != nil {
+		c.lastHealth = ClusterHealth{Status: "red"}
+	} else {
+		c.lastHealth = health
+	}
+	c.healthMu.Unlock()
+
+	if err != nil || health.Status == "red" {
+		c.breaker.Trip()
+	}
+}
+
+// fetchClusterHealth runs the Cluster Health API and decodes the fields
+// ClusterHealth cares about.
+func (c *Client) fetchClusterHealth(ctx context.Context) (ClusterHealth, error) {
+	req := opensearchapi.ClusterHealthRequest{}
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return ClusterHealth{}, fmt.Errorf("cluster health: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return ClusterHealth{}, fmt.Errorf("cluster health error: %s", res.String())
+	}
+
+	var health ClusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return ClusterHealth{}, fmt.Errorf("decode cluster health: %w", err)
+	}
+	return health, nil
+}
+
+// Available reports whether the last health check saw a non-red cluster and
+// the circuit breaker isn't open, i.e. whether a caller should expect
+// BulkIndex/CreateIndex/HybridSearch to succeed right now.
+func (c *Client) Available() bool {
+	c.healthMu.RLock()
+	status := c.lastHealth.Status
+	c.healthMu.RUnlock()
+
+	return status != "red" && c.breaker.String() != "open"
+}
+
+// LastHealth returns the most recent background health check result, for
+// the status command to report cluster status/node count/pending tasks
+// without blocking on a fresh round trip.
+func (c *Client) LastHealth() ClusterHealth {
+	c.healthMu.RLock()
+	defer c.healthMu.RUnlock()
+	return c.lastHealth
+}
+
+// BreakerState returns the circuit breaker's current state ("closed",
+// "open", or "half-open"), for the status command.
+func (c *Client) BreakerState() string {
+	return c.breaker.String()
+}
+
+// WaitReady blocks until Available reports true, ctx is done, or timeout
+// elapses, whichever comes first - so a caller that just started up can
+// wait out a brief OpenSearch restart instead of failing its first request.
+func (c *Client) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if c.Available() {
+		return nil
+	}
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("opensearch not ready after %s: %w", timeout, ErrBackendUnavailable)
+		case <-ticker.C:
+			if c.Available() {
+				return nil
+			}
+		}
+	}
+}
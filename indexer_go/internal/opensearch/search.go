@@ -0,0 +1,361 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+// defaultRRFK is the Reciprocal Rank Fusion rank-smoothing constant used
+// when SearchRequest.RRFK is unset. Higher values flatten the influence of
+// rank differences near the top of each list.
+const defaultRRFK = 60
+
+// defaultSearchTopK is the number of hits returned when SearchRequest.TopK
+// is unset.
+const defaultSearchTopK = 10
+
+// candidateMultiplier controls how many hits are pulled from each branch
+// (BM25, k-NN) before fusion, relative to TopK, so RRF has enough of a tail
+// from both lists to actually move the final ranking.
+const candidateMultiplier = 5
+
+// SearchFilters narrows a HybridSearch to a subset of the index. A zero
+// value (all fields empty/zero) applies no filtering.
+type SearchFilters struct {
+	YearFrom          int    // publication_year >= YearFrom, if non-zero
+	YearTo            int    // publication_year <= YearTo, if non-zero
+	SubjectArea       string // exact match against subject_area.keyword, if non-empty
+	DocumentType      string // exact match against document_type, if non-empty
+	HasMatchedProfile *bool  // at least one author has_matched_profile == *HasMatchedProfile, if set
+}
+
+// SearchRequest describes a hybrid search: a BM25 query over QueryText and
+// a k-NN query over QueryEmbedding, fused via Reciprocal Rank Fusion.
+// Leaving QueryEmbedding empty falls back to BM25-only; leaving QueryText
+// empty falls back to k-NN-only. At least one of the two must be set.
+type SearchRequest struct {
+	QueryText      string
+	QueryEmbedding []float32
+	TopK           int // Results to return; defaults to defaultSearchTopK
+	Filters        SearchFilters
+
+	// RRFK is the RRF rank-smoothing constant ("rrf_k"); defaults to
+	// defaultRRFK. BM25Weight/KNNWeight scale each branch's contribution to
+	// the fused score before summing; both default to 1.0.
+	RRFK       int
+	BM25Weight float64
+	KNNWeight  float64
+}
+
+// SearchHit is one fused, de-duplicated result.
+type SearchHit struct {
+	MongoID string
+	Source  OSDocument
+
+	// Score is the fused RRF score used to rank hits; it has no meaning
+	// outside of this result set.
+	Score float64
+	// BM25Rank and KNNRank are this document's 1-based rank in each
+	// branch's candidate list, or 0 if it didn't appear in that branch at
+	// all (e.g. a k-NN-only fallback leaves BM25Rank at 0 for every hit).
+	BM25Rank int
+	KNNRank  int
+	// KNNScore is the raw cosine similarity score from the k-NN branch, 0
+	// if this hit only came from BM25.
+	KNNScore float64
+	// Highlights carries the BM25 branch's title.shingles/abstract.shingles
+	// fragments, nil if this hit only came from k-NN.
+	Highlights map[string][]string
+}
+
+// SearchResponse is the fused, ranked result of a HybridSearch call.
+type SearchResponse struct {
+	Hits []SearchHit
+	// BM25Only/KNNOnly report which branch(es) actually ran, so callers
+	// can tell a genuine tie-break from "the other branch was skipped".
+	BM25Only bool
+	KNNOnly  bool
+}
+
+// msearchHit and msearchResponse mirror the bits of OpenSearch's _msearch
+// response this package needs; everything else is ignored by json.Decode.
+type msearchHit struct {
+	ID        string              `json:"_id"`
+	Score     float64             `json:"_score"`
+	Source    OSDocument          `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+}
+
+type msearchResponse struct {
+	Responses []struct {
+		Hits struct {
+			Hits []msearchHit `json:"hits"`
+		} `json:"hits"`
+		Error *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"responses"`
+}
+
+// HybridSearch runs a BM25 query over title/abstract/author_name_variants
+// and a k-NN query over embedding in a single msearch round trip, then
+// fuses the two ranked lists with Reciprocal Rank Fusion: each doc scores
+// sum_over_lists(weight_i / (rrf_k + rank_i)), rank starting at 1. Hits
+// that only appear in one branch (e.g. a BM25-only fallback) are scored
+// from that branch alone.
+func (c *Client) HybridSearch(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	if !c.breaker.Allow() {
+		return nil, ErrBackendUnavailable
+	}
+
+	runBM25 := req.QueryText != ""
+	runKNN := len(req.QueryEmbedding) > 0
+	if !runBM25 && !runKNN {
+		return nil, fmt.Errorf("search request needs QueryText, QueryEmbedding, or both")
+	}
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = defaultSearchTopK
+	}
+	rrfK := req.RRFK
+	if rrfK <= 0 {
+		rrfK = defaultRRFK
+	}
+	bm25Weight := req.BM25Weight
+	if bm25Weight == 0 {
+		bm25Weight = 1.0
+	}
+	knnWeight := req.KNNWeight
+	if knnWeight == 0 {
+		knnWeight = 1.0
+	}
+	candidateSize := topK * candidateMultiplier
+
+	filters := buildFilterClauses(req.Filters)
+
+	var buf bytes.Buffer
+	if runBM25 {
+		writeMsearchItem(&buf, buildBM25Query(req.QueryText, filters, candidateSize))
+	}
+	if runKNN {
+		writeMsearchItem(&buf, buildKNNQuery(req.QueryEmbedding, filters, candidateSize))
+	}
+
+	msearchReq := opensearchapi.MsearchRequest{
+		Index: []string{c.cfg.OpenSearchIndex},
+		Body:  &buf,
+	}
+	res, err := msearchReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("msearch request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("msearch error: %s", res.String())
+	}
+
+	var parsed msearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode msearch response: %w", err)
+	}
+
+	var bm25Hits, knnHits []msearchHit
+	i := 0
+	if runBM25 {
+		if parsed.Responses[i].Error != nil {
+			return nil, fmt.Errorf("bm25 branch error: %s", parsed.Responses[i].Error.Reason)
+		}
+		bm25Hits = parsed.Responses[i].Hits.Hits
+		i++
+	}
+	if runKNN {
+		if parsed.Responses[i].Error != nil {
+			return nil, fmt.Errorf("knn branch error: %s", parsed.Responses[i].Error.Reason)
+		}
+		knnHits = parsed.Responses[i].Hits.Hits
+	}
+
+	hits := fuseRRF(bm25Hits, knnHits, bm25Weight, knnWeight, rrfK)
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+
+	return &SearchResponse{
+		Hits:     hits,
+		BM25Only: runBM25 && !runKNN,
+		KNNOnly:  runKNN && !runBM25,
+	}, nil
+}
+
+// fuseRRF merges two ranked hit lists into one, scoring each doc id by
+// sum_over_lists(weight_i / (rrf_k + rank_i)) and keeping the BM25 branch's
+// highlights alongside the k-NN branch's cosine score.
+func fuseRRF(bm25Hits, knnHits []msearchHit, bm25Weight, knnWeight float64, rrfK int) []SearchHit {
+	byID := make(map[string]*SearchHit)
+	order := make([]string, 0, len(bm25Hits)+len(knnHits))
+
+	get := func(hit msearchHit) *SearchHit {
+		h, ok := byID[hit.ID]
+		if !ok {
+			h = &SearchHit{MongoID: hit.ID, Source: hit.Source}
+			byID[hit.ID] = h
+			order = append(order, hit.ID)
+		}
+		return h
+	}
+
+	for rank, hit := range bm25Hits {
+		h := get(hit)
+		h.BM25Rank = rank + 1
+		h.Highlights = hit.Highlight
+		h.Score += bm25Weight / float64(rrfK+rank+1)
+	}
+	for rank, hit := range knnHits {
+		h := get(hit)
+		h.KNNRank = rank + 1
+		h.KNNScore = hit.Score
+		h.Score += knnWeight / float64(rrfK+rank+1)
+	}
+
+	hits := make([]SearchHit, 0, len(order))
+	for _, id := range order {
+		hits = append(hits, *byID[id])
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
+
+// writeMsearchItem appends one msearch header/query pair to buf. The
+// header is left empty since Index is already scoped via the msearch
+// request's top-level Index field.
+func writeMsearchItem(buf *bytes.Buffer, query map[string]interface{}) {
+	buf.WriteString("{}\n")
+	body, _ := json.Marshal(query)
+	buf.Write(body)
+	buf.WriteByte('\n')
+}
+
+// buildFilterClauses translates SearchFilters into a list of bool-query
+// filter clauses shared by both the BM25 and k-NN branches, so a filtered
+// search narrows the same candidate set on both sides of the fusion.
+func buildFilterClauses(f SearchFilters) []map[string]interface{} {
+	var clauses []map[string]interface{}
+
+	if f.YearFrom != 0 || f.YearTo != 0 {
+		yearRange := map[string]interface{}{}
+		if f.YearFrom != 0 {
+			yearRange["gte"] = f.YearFrom
+		}
+		if f.YearTo != 0 {
+			yearRange["lte"] = f.YearTo
+		}
+		clauses = append(clauses, map[string]interface{}{
+			"range": map[string]interface{}{"publication_year": yearRange},
+		})
+	}
+	if f.SubjectArea != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{"subject_area.keyword": f.SubjectArea},
+		})
+	}
+	if f.DocumentType != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{"document_type": f.DocumentType},
+		})
+	}
+	if f.HasMatchedProfile != nil {
+		clauses = append(clauses, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "authors",
+				"query": map[string]interface{}{
+					"term": map[string]interface{}{"authors.has_matched_profile": *f.HasMatchedProfile},
+				},
+			},
+		})
+	}
+	return clauses
+}
+
+// buildBM25Query builds a BM25 search over title/abstract plus a nested
+// clause over authors.author_name_variants, so a query matching an
+// author's name variant contributes even though it lives inside a nested
+// document. Highlights are requested on the shingle sub-fields for
+// phrase-aware snippets.
+func buildBM25Query(queryText string, filters []map[string]interface{}, size int) map[string]interface{} {
+	should := []map[string]interface{}{
+		{
+			"multi_match": map[string]interface{}{
+				"query":  queryText,
+				"fields": []string{"title^2", "abstract"},
+			},
+		},
+		{
+			"nested": map[string]interface{}{
+				"path":       "authors",
+				"score_mode": "max",
+				"query": map[string]interface{}{
+					"match": map[string]interface{}{"authors.author_name_variants": queryText},
+				},
+			},
+		},
+	}
+
+	boolQuery := map[string]interface{}{
+		"should":               should,
+		"minimum_should_match": 1,
+	}
+	if len(filters) > 0 {
+		boolQuery["filter"] = filters
+	}
+
+	return map[string]interface{}{
+		"size":  size,
+		"query": map[string]interface{}{"bool": boolQuery},
+		"highlight": map[string]interface{}{
+			"fields": map[string]interface{}{
+				"title.shingles":    map[string]interface{}{},
+				"abstract.shingles": map[string]interface{}{},
+			},
+		},
+	}
+}
+
+// buildKNNQuery builds a k-NN query over the embedding field, with
+// Filters applied as bool filter clauses alongside it so a filtered search
+// still draws its candidate pool from the full filtered set rather than
+// filtering after the fact.
+func buildKNNQuery(embedding []float32, filters []map[string]interface{}, size int) map[string]interface{} {
+	knnClause := map[string]interface{}{
+		"knn": map[string]interface{}{
+			"embedding": map[string]interface{}{
+				"vector": embedding,
+				"k":      size,
+			},
+		},
+	}
+
+	if len(filters) == 0 {
+		return map[string]interface{}{
+			"size":  size,
+			"query": knnClause,
+		}
+	}
+
+	return map[string]interface{}{
+		"size": size,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   []map[string]interface{}{knnClause},
+				"filter": filters,
+			},
+		},
+	}
+}
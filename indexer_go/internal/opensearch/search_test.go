@@ -0,0 +1,77 @@
+package opensearch
+
+import "testing"
+
+func TestFuseRRFOrdersByCombinedScore(t *testing.T) {
+	bm25Hits := []msearchHit{
+		{ID: "a"},
+		{ID: "b"},
+		{ID: "c"},
+	}
+	knnHits := []msearchHit{
+		{ID: "c", Score: 0.9},
+		{ID: "a", Score: 0.8},
+	}
+
+	hits := fuseRRF(bm25Hits, knnHits, 1.0, 1.0, 60)
+
+	if len(hits) != 3 {
+		t.Fatalf("got %d hits, want 3", len(hits))
+	}
+	// "a" and "c" each appear in both branches so they should outrank "b",
+	// which only appears in BM25.
+	if hits[2].MongoID != "b" {
+		t.Fatalf("expected doc only present in one branch to rank last, got order %v", hitIDs(hits))
+	}
+	for _, h := range hits[:2] {
+		if h.MongoID != "a" && h.MongoID != "c" {
+			t.Fatalf("expected top two hits to be a/c, got %v", hitIDs(hits))
+		}
+	}
+}
+
+func TestFuseRRFPreservesPerBranchRanksAndScores(t *testing.T) {
+	bm25Hits := []msearchHit{{ID: "x"}, {ID: "y"}}
+	knnHits := []msearchHit{{ID: "y", Score: 0.5}}
+
+	hits := fuseRRF(bm25Hits, knnHits, 1.0, 1.0, 60)
+
+	byID := make(map[string]SearchHit)
+	for _, h := range hits {
+		byID[h.MongoID] = h
+	}
+
+	x := byID["x"]
+	if x.BM25Rank != 1 || x.KNNRank != 0 {
+		t.Errorf("doc x: got BM25Rank=%d KNNRank=%d, want 1/0", x.BM25Rank, x.KNNRank)
+	}
+
+	y := byID["y"]
+	if y.BM25Rank != 2 || y.KNNRank != 1 {
+		t.Errorf("doc y: got BM25Rank=%d KNNRank=%d, want 2/1", y.BM25Rank, y.KNNRank)
+	}
+	if y.KNNScore != 0.5 {
+		t.Errorf("doc y: got KNNScore=%v, want 0.5", y.KNNScore)
+	}
+}
+
+func TestFuseRRFKnnOnlyHasNoBM25Rank(t *testing.T) {
+	knnHits := []msearchHit{{ID: "z", Score: 1.0}}
+
+	hits := fuseRRF(nil, knnHits, 1.0, 1.0, 60)
+
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	if hits[0].BM25Rank != 0 {
+		t.Errorf("got BM25Rank=%d, want 0 for a hit only present in the k-NN branch", hits[0].BM25Rank)
+	}
+}
+
+func hitIDs(hits []SearchHit) []string {
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.MongoID
+	}
+	return ids
+}
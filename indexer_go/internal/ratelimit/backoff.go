@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff returns a jittered exponential delay for retry attempt (0-based):
+// base * 2^attempt, capped at max, then randomized to somewhere in
+// [0.5x, 1.5x) of that so a burst of callers retrying a rate-limited
+// cluster at once don't all retry in lockstep.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << attempt // attempt is always small (retry counts are bounded); overflow isn't a practical concern
+	if d <= 0 || d > max {
+		d = max
+	}
+	jitter := 0.5 + rand.Float64()
+	return time.Duration(float64(d) * jitter)
+}
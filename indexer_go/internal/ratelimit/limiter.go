@@ -0,0 +1,125 @@
+// Package ratelimit provides a token-bucket Limiter whose sustained rate
+// adapts by AIMD, shared by the mongodb package's reads (StreamDocuments)
+// and writes (BulkUpdateOpenSearchIDs) so a struggling cluster throttles
+// both instead of one racing ahead of the other.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter wraps a golang.org/x/time/rate.Limiter whose limit Success and
+// Failure adjust by AIMD: Success additively grows it back towards max,
+// Failure (reported for rate-limit-shaped errors) multiplicatively halves
+// it down to min. Inflight and error counts are tracked alongside the rate
+// so callers can surface them (e.g. through cli.Progress) without
+// recomputing anything.
+type Limiter struct {
+	mu   sync.Mutex
+	rl   *rate.Limiter
+	rps  float64
+	min  float64
+	max  float64
+	step float64
+
+	inflight int64
+	errors   int64
+}
+
+// New creates a Limiter starting at max requests/sec (the ceiling it grows
+// back towards), floored at min, growing by step per Success. burst is the
+// token bucket's burst size. Non-positive max/min/step/burst fall back to
+// sane defaults so a zero-value config doesn't produce a Limiter that
+// never lets anything through.
+func New(min, max, step float64, burst int) *Limiter {
+	if max <= 0 {
+		max = 10
+	}
+	if min <= 0 || min > max {
+		min = max / 8
+	}
+	if step <= 0 {
+		step = max / 10
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rl:   rate.NewLimiter(rate.Limit(max), burst),
+		rps:  max,
+		min:  min,
+		max:  max,
+		step: step,
+	}
+}
+
+// Wait blocks until a token is available and marks the request inflight.
+// Callers must report the outcome via Success or Failure exactly once per
+// Wait that returns nil.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if err := l.rl.Wait(ctx); err != nil {
+		return err
+	}
+	atomic.AddInt64(&l.inflight, 1)
+	return nil
+}
+
+// Success grows the rate one step back towards its ceiling and marks the
+// request no longer inflight.
+func (l *Limiter) Success() {
+	atomic.AddInt64(&l.inflight, -1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rps += l.step
+	if l.rps > l.max {
+		l.rps = l.max
+	}
+	l.rl.SetLimit(rate.Limit(l.rps))
+}
+
+// Failure halves the rate (floored at min), records the error, and marks
+// the request no longer inflight. Call for any error that looks like
+// cluster-side throttling.
+func (l *Limiter) Failure() {
+	atomic.AddInt64(&l.inflight, -1)
+	atomic.AddInt64(&l.errors, 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rps /= 2
+	if l.rps < l.min {
+		l.rps = l.min
+	}
+	l.rl.SetLimit(rate.Limit(l.rps))
+}
+
+// Rate returns the current sustained requests/sec the limiter enforces.
+func (l *Limiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rps
+}
+
+// Throttled reports whether the rate has backed off below its ceiling.
+func (l *Limiter) Throttled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rps < l.max
+}
+
+// Inflight returns the number of Wait calls not yet resolved by Success or
+// Failure.
+func (l *Limiter) Inflight() int64 {
+	return atomic.LoadInt64(&l.inflight)
+}
+
+// Errors returns the cumulative number of Failure calls observed.
+func (l *Limiter) Errors() int64 {
+	return atomic.LoadInt64(&l.errors)
+}
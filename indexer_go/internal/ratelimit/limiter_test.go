@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterFailureHalvesRateFlooredAtMin(t *testing.T) {
+	l := New(2, 16, 1, 1)
+	if l.Rate() != 16 {
+		t.Fatalf("got initial Rate()=%v, want 16", l.Rate())
+	}
+
+	l.Failure()
+	if l.Rate() != 8 {
+		t.Fatalf("got Rate()=%v after one Failure, want 8", l.Rate())
+	}
+
+	l.Failure()
+	l.Failure()
+	l.Failure()
+	if l.Rate() != 2 {
+		t.Fatalf("got Rate()=%v after repeated Failure, want floored at min=2", l.Rate())
+	}
+	if !l.Throttled() {
+		t.Fatal("expected Throttled() once rate has backed off below max")
+	}
+}
+
+func TestLimiterSuccessGrowsBackTowardsMax(t *testing.T) {
+	l := New(2, 16, 4, 1)
+	l.Failure() // 16 -> 8
+
+	l.Success() // 8 -> 12
+	if l.Rate() != 12 {
+		t.Fatalf("got Rate()=%v after Success, want 12", l.Rate())
+	}
+
+	l.Success() // 12 -> 16 (capped)
+	l.Success() // stays at 16
+	if l.Rate() != 16 {
+		t.Fatalf("got Rate()=%v, want capped at max=16", l.Rate())
+	}
+	if l.Throttled() {
+		t.Fatal("expected Throttled() to be false once rate recovers to max")
+	}
+}
+
+func TestLimiterTracksInflightAndErrors(t *testing.T) {
+	l := New(2, 16, 4, 4)
+	ctx := context.Background()
+
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if l.Inflight() != 1 {
+		t.Fatalf("got Inflight()=%d after Wait, want 1", l.Inflight())
+	}
+
+	l.Failure()
+	if l.Inflight() != 0 {
+		t.Fatalf("got Inflight()=%d after Failure, want 0", l.Inflight())
+	}
+	if l.Errors() != 1 {
+		t.Fatalf("got Errors()=%d, want 1", l.Errors())
+	}
+}
+
+func TestNewAppliesDefaultsForNonPositiveArgs(t *testing.T) {
+	l := New(0, 0, 0, 0)
+	if l.Rate() != 10 {
+		t.Fatalf("got Rate()=%v, want default max=10 when max<=0", l.Rate())
+	}
+}
+
+func TestBackoffCapsAtMax(t *testing.T) {
+	base := time.Second
+	max := 10 * time.Second
+	for attempt := 0; attempt < 10; attempt++ {
+		d := Backoff(attempt, base, max)
+		if d < 0 || d > time.Duration(1.5*float64(max)) {
+			t.Fatalf("attempt %d: got Backoff=%v, want within [0, 1.5*max]", attempt, d)
+		}
+	}
+}
+
+func TestBackoffGrowsWithAttempt(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := time.Hour // high enough that neither attempt below hits the cap
+
+	// Jitter is +/-50% of base*2^attempt, so compare attempt 5's
+	// worst-case-low against attempt 1's best-case-high to confirm the
+	// exponential trend deterministically, without relying on randomness.
+	for i := 0; i < 20; i++ {
+		lo5 := float64(base<<5) * 0.5
+		hi1 := float64(base<<1) * 1.5
+		if lo5 <= hi1 {
+			t.Fatalf("attempt 5's minimum backoff (%v) should exceed attempt 1's maximum (%v)", time.Duration(lo5), time.Duration(hi1))
+		}
+		if d := Backoff(5, base, max); float64(d) < lo5 || float64(d) > float64(base<<5)*1.5 {
+			t.Fatalf("Backoff(5, ...) = %v out of expected jitter range", d)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package search abstracts the operations the indexer pipeline needs from
+// a full-text/vector search engine behind a single Backend interface, so
+// Phase 2 and the search CLI command don't have to care whether they're
+// talking to a real OpenSearch cluster or an embedded, in-process engine.
+// See OpenSearchBackend for the production implementation and BleveBackend
+// for the local/offline one.
+package search
+
+import "context"
+
+// Author mirrors opensearch.OSAuthor - one nested author entry on a
+// Document.
+type Author struct {
+	AuthorID           string
+	AuthorName         string
+	AuthorNameVariants []string
+	AuthorPosition     int
+	AuthorAffiliation  string
+	AuthorEmail        string
+	HasMatchedProfile  bool
+}
+
+// Document is the backend-neutral shape of one indexable research
+// document. It mirrors opensearch.OSDocument field-for-field so converting
+// between the two is lossless.
+type Document struct {
+	MongoID            string
+	Title              string
+	Abstract           string
+	Authors            []Author
+	AuthorNames        []string
+	AuthorNameVariants []string
+	PublicationYear    int
+	FieldAssociated    string
+	DocumentType       string
+	SubjectArea        []string
+	SubjectAreaCount   int
+	CitationCount      int
+	ReferenceCount     int
+	Embedding          []float32
+}
+
+// Filters narrows a Search to a subset of the index. A zero value (all
+// fields empty/zero) applies no filtering. Mirrors
+// opensearch.SearchFilters.
+type Filters struct {
+	YearFrom          int
+	YearTo            int
+	SubjectArea       string
+	DocumentType      string
+	HasMatchedProfile *bool
+}
+
+// Request describes a hybrid search: a BM25 query over QueryText and a
+// k-NN query over QueryEmbedding, fused via Reciprocal Rank Fusion.
+// Leaving QueryEmbedding empty falls back to BM25-only; leaving QueryText
+// empty falls back to k-NN-only. At least one of the two must be set.
+// Mirrors opensearch.SearchRequest.
+type Request struct {
+	QueryText      string
+	QueryEmbedding []float32
+	TopK           int
+	Filters        Filters
+
+	RRFK       int
+	BM25Weight float64
+	KNNWeight  float64
+}
+
+// Hit is one fused, de-duplicated result. Mirrors opensearch.SearchHit.
+type Hit struct {
+	MongoID string
+	Source  Document
+
+	Score      float64
+	BM25Rank   int
+	KNNRank    int
+	KNNScore   float64
+	Highlights map[string][]string
+}
+
+// Response is the fused, ranked result of a Search call. Mirrors
+// opensearch.SearchResponse.
+type Response struct {
+	Hits     []Hit
+	BM25Only bool
+	KNNOnly  bool
+}
+
+// Backend is implemented by every search engine the indexer pipeline can
+// write to and query, so Phase 2 and the search CLI command don't depend
+// on the concrete engine. OpenSearchBackend wraps opensearch.Client for
+// production use; BleveBackend is an embedded, in-process engine for
+// local development and CI.
+type Backend interface {
+	// EnsureIndex makes sure the backend's backing index/store exists and
+	// is ready to accept writes, creating it if necessary.
+	EnsureIndex(ctx context.Context) error
+	// BulkIndex writes docs and returns a map from MongoID to the
+	// backend's internal document ID for every document it indexed
+	// successfully. A document missing from the returned map failed to
+	// index; err is non-nil only for a failure that aborted the whole
+	// call (e.g. the backend was unreachable).
+	BulkIndex(ctx context.Context, docs []Document) (map[string]string, error)
+	// DeleteByMongoIDs removes documents by MongoID, for change-stream
+	// delete events.
+	DeleteByMongoIDs(ctx context.Context, mongoIDs []string) error
+	// Search runs a hybrid BM25 + k-NN query and returns fused, ranked
+	// hits.
+	Search(ctx context.Context, req Request) (*Response, error)
+	// Close releases any resources the backend holds.
+	Close() error
+}
@@ -0,0 +1,342 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	bleveDefaultTopK          = 10
+	bleveDefaultRRFK          = 60
+	bleveCandidateMultiplier  = 5
+	bleveDefaultBM25KNNWeight = 1.0
+)
+
+// bleveDocument is the shape indexed into Bleve's full-text engine. The
+// embedding is kept out of it deliberately - Bleve has no native vector
+// field, so BleveBackend keeps embeddings in an in-memory doc-value store
+// (documents, keyed by mongo_id) and scores them with a brute-force cosine
+// scan instead.
+type bleveDocument struct {
+	Title              string `json:"title"`
+	Abstract           string `json:"abstract"`
+	AuthorNames        string `json:"author_names"`
+	AuthorNameVariants string `json:"author_name_variants"`
+	FieldAssociated    string `json:"field_associated"`
+	DocumentType       string `json:"document_type"`
+	SubjectArea        string `json:"subject_area"`
+	PublicationYear    int    `json:"publication_year"`
+}
+
+// BleveBackend is an embedded, in-process search.Backend backed by
+// github.com/blevesearch/bleve/v2, for local development and CI where
+// running a full OpenSearch cluster isn't practical. Bleve provides the
+// BM25 side natively; the k-NN side is a brute-force cosine scan over the
+// documents map, since Bleve has no native HNSW index.
+type BleveBackend struct {
+	mu        sync.RWMutex
+	index     bleve.Index
+	documents map[string]Document // mongo_id -> full document
+}
+
+// NewBleveBackend creates an empty, in-memory Bleve-backed Backend.
+func NewBleveBackend() (*BleveBackend, error) {
+	index, err := bleve.NewMemOnly(bleveIndexMapping())
+	if err != nil {
+		return nil, fmt.Errorf("create bleve index: %w", err)
+	}
+	return &BleveBackend{
+		index:     index,
+		documents: make(map[string]Document),
+	}, nil
+}
+
+// bleveIndexMapping maps title/abstract/author fields to English-analyzed
+// text, and the rest to unanalyzed keyword/numeric fields for filtering.
+func bleveIndexMapping() *mapping.IndexMappingImpl {
+	englishText := bleve.NewTextFieldMapping()
+	englishText.Analyzer = "en"
+
+	keyword := bleve.NewTextFieldMapping()
+	keyword.Analyzer = "keyword"
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("title", englishText)
+	doc.AddFieldMappingsAt("abstract", englishText)
+	doc.AddFieldMappingsAt("author_names", englishText)
+	doc.AddFieldMappingsAt("author_name_variants", englishText)
+	doc.AddFieldMappingsAt("field_associated", keyword)
+	doc.AddFieldMappingsAt("document_type", keyword)
+	doc.AddFieldMappingsAt("subject_area", keyword)
+
+	im := bleve.NewIndexMapping()
+	im.DefaultMapping = doc
+	return im
+}
+
+// EnsureIndex is a no-op: the in-memory index always exists once
+// NewBleveBackend has returned.
+func (b *BleveBackend) EnsureIndex(ctx context.Context) error {
+	return nil
+}
+
+// BulkIndex indexes docs into Bleve and records their embeddings for the
+// brute-force k-NN scan. Bleve has no separate backing-store ID, so the
+// returned map uses MongoID as its own value.
+func (b *BleveBackend) BulkIndex(ctx context.Context, docs []Document) (map[string]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	idMap := make(map[string]string, len(docs))
+	for _, d := range docs {
+		if err := b.index.Index(d.MongoID, toBleveDocument(d)); err != nil {
+			return nil, fmt.Errorf("index %s: %w", d.MongoID, err)
+		}
+		b.documents[d.MongoID] = d
+		idMap[d.MongoID] = d.MongoID
+	}
+	return idMap, nil
+}
+
+// DeleteByMongoIDs removes documents from both the full-text index and the
+// embedding store.
+func (b *BleveBackend) DeleteByMongoIDs(ctx context.Context, mongoIDs []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, id := range mongoIDs {
+		if err := b.index.Delete(id); err != nil {
+			return fmt.Errorf("delete %s: %w", id, err)
+		}
+		delete(b.documents, id)
+	}
+	return nil
+}
+
+// Search runs Bleve's BM25 query and a brute-force cosine k-NN scan, then
+// fuses both ranked lists via Reciprocal Rank Fusion - the same algorithm
+// opensearch.Client.HybridSearch uses, reimplemented here over plain
+// mongo_id rank lists since Bleve's result shape doesn't match OpenSearch's
+// msearch response.
+func (b *BleveBackend) Search(ctx context.Context, req Request) (*Response, error) {
+	if req.QueryText == "" && len(req.QueryEmbedding) == 0 {
+		return nil, fmt.Errorf("search requires QueryText, QueryEmbedding, or both")
+	}
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = bleveDefaultTopK
+	}
+	rrfK := req.RRFK
+	if rrfK <= 0 {
+		rrfK = bleveDefaultRRFK
+	}
+	bm25Weight := req.BM25Weight
+	if bm25Weight == 0 {
+		bm25Weight = bleveDefaultBM25KNNWeight
+	}
+	knnWeight := req.KNNWeight
+	if knnWeight == 0 {
+		knnWeight = bleveDefaultBM25KNNWeight
+	}
+	candidates := topK * bleveCandidateMultiplier
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var bm25Ranked []string
+	var knnRanked []scoredID
+	bm25Only, knnOnly := false, false
+
+	if req.QueryText != "" {
+		ranked, err := b.bm25Search(req, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("bm25 search: %w", err)
+		}
+		bm25Ranked = ranked
+	} else {
+		knnOnly = true
+	}
+
+	if len(req.QueryEmbedding) > 0 {
+		knnRanked = b.knnSearch(req.QueryEmbedding, candidates)
+	} else {
+		bm25Only = true
+	}
+
+	hits := fuseRanked(bm25Ranked, knnRanked, bm25Weight, knnWeight, rrfK)
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+	for i := range hits {
+		hits[i].Source = b.documents[hits[i].MongoID]
+	}
+
+	return &Response{Hits: hits, BM25Only: bm25Only, KNNOnly: knnOnly}, nil
+}
+
+// Close releases the in-memory index.
+func (b *BleveBackend) Close() error {
+	return b.index.Close()
+}
+
+func toBleveDocument(d Document) bleveDocument {
+	return bleveDocument{
+		Title:              d.Title,
+		Abstract:           d.Abstract,
+		AuthorNames:        joinStrings(d.AuthorNames),
+		AuthorNameVariants: joinStrings(d.AuthorNameVariants),
+		FieldAssociated:    d.FieldAssociated,
+		DocumentType:       d.DocumentType,
+		SubjectArea:        joinStrings(d.SubjectArea),
+		PublicationYear:    d.PublicationYear,
+	}
+}
+
+func joinStrings(vals []string) string {
+	out := ""
+	for i, v := range vals {
+		if i > 0 {
+			out += " "
+		}
+		out += v
+	}
+	return out
+}
+
+// bm25Search runs req's text query (plus any Filters) against the Bleve
+// index and returns the top `limit` document IDs, best match first.
+func (b *BleveBackend) bm25Search(req Request, limit int) ([]string, error) {
+	sr := bleve.NewSearchRequestOptions(bleveQuery(req), limit, 0, false)
+	res, err := b.index.Search(sr)
+	if err != nil {
+		return nil, err
+	}
+	ranked := make([]string, len(res.Hits))
+	for i, h := range res.Hits {
+		ranked[i] = h.ID
+	}
+	return ranked, nil
+}
+
+// bleveQuery builds the conjunction of a match query over QueryText and
+// any Filters. HasMatchedProfile has no per-author representation in the
+// flattened bleveDocument, so it's a documented limitation of this
+// dev/CI-only backend and is silently ignored.
+func bleveQuery(req Request) query.Query {
+	must := []query.Query{bleve.NewMatchQuery(req.QueryText)}
+
+	if req.Filters.DocumentType != "" {
+		q := bleve.NewTermQuery(req.Filters.DocumentType)
+		q.SetField("document_type")
+		must = append(must, q)
+	}
+	if req.Filters.SubjectArea != "" {
+		q := bleve.NewMatchQuery(req.Filters.SubjectArea)
+		q.SetField("subject_area")
+		must = append(must, q)
+	}
+	if req.Filters.YearFrom > 0 || req.Filters.YearTo > 0 {
+		var min, max *float64
+		if req.Filters.YearFrom > 0 {
+			v := float64(req.Filters.YearFrom)
+			min = &v
+		}
+		if req.Filters.YearTo > 0 {
+			v := float64(req.Filters.YearTo)
+			max = &v
+		}
+		q := bleve.NewNumericRangeQuery(min, max)
+		q.SetField("publication_year")
+		must = append(must, q)
+	}
+
+	return bleve.NewConjunctionQuery(must...)
+}
+
+type scoredID struct {
+	id    string
+	score float32
+}
+
+// knnSearch brute-force scans every stored embedding and returns the top
+// `limit` mongo_ids by cosine similarity to queryEmbedding, best first.
+func (b *BleveBackend) knnSearch(queryEmbedding []float32, limit int) []scoredID {
+	scores := make([]scoredID, 0, len(b.documents))
+	for id, doc := range b.documents {
+		if len(doc.Embedding) != len(queryEmbedding) {
+			continue
+		}
+		scores = append(scores, scoredID{id: id, score: cosineSimilarity(doc.Embedding, queryEmbedding)})
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+	return scores
+}
+
+func cosineSimilarity(a, b []float32) float32 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+// fuseRanked combines a BM25 rank list and a scored k-NN rank list via
+// Reciprocal Rank Fusion: score = sum_over_lists(weight / (rrfK + rank)).
+func fuseRanked(bm25Ranked []string, knnRanked []scoredID, bm25Weight, knnWeight float64, rrfK int) []Hit {
+	type acc struct {
+		score    float64
+		bm25Rank int
+		knnRank  int
+		knnScore float32
+	}
+	accum := make(map[string]*acc)
+
+	for i, id := range bm25Ranked {
+		a := accum[id]
+		if a == nil {
+			a = &acc{}
+			accum[id] = a
+		}
+		a.bm25Rank = i + 1
+		a.score += bm25Weight / float64(rrfK+i+1)
+	}
+	for i, s := range knnRanked {
+		a := accum[s.id]
+		if a == nil {
+			a = &acc{}
+			accum[s.id] = a
+		}
+		a.knnRank = i + 1
+		a.knnScore = s.score
+		a.score += knnWeight / float64(rrfK+i+1)
+	}
+
+	hits := make([]Hit, 0, len(accum))
+	for id, a := range accum {
+		hits = append(hits, Hit{
+			MongoID:  id,
+			Score:    a.score,
+			BM25Rank: a.bm25Rank,
+			KNNRank:  a.knnRank,
+			KNNScore: float64(a.knnScore),
+		})
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
@@ -0,0 +1,162 @@
+package search
+
+import (
+	"context"
+
+	"github.com/sudarshan/indexer/internal/opensearch"
+)
+
+// OpenSearchBackend adapts an *opensearch.Client to the Backend interface.
+// It's a thin translation layer: every call converts to/from the
+// OpenSearch wire types and delegates straight to the client.
+type OpenSearchBackend struct {
+	client *opensearch.Client
+}
+
+// NewOpenSearchBackend wraps client as a Backend.
+func NewOpenSearchBackend(client *opensearch.Client) *OpenSearchBackend {
+	return &OpenSearchBackend{client: client}
+}
+
+// Client returns the wrapped *opensearch.Client, for callers that still
+// need OpenSearch-specific operations Backend doesn't expose (index
+// generations, alias swaps, mapping-version reindexes) - nothing Bleve has
+// an analog for, so these stay outside the interface.
+func (b *OpenSearchBackend) Client() *opensearch.Client {
+	return b.client
+}
+
+// EnsureIndex creates the configured index if it doesn't already exist.
+func (b *OpenSearchBackend) EnsureIndex(ctx context.Context) error {
+	return b.client.CreateIndex(ctx)
+}
+
+// BulkIndex converts docs to opensearch.OSDocument and bulk-indexes them.
+func (b *OpenSearchBackend) BulkIndex(ctx context.Context, docs []Document) (map[string]string, error) {
+	osDocs := make([]opensearch.OSDocument, len(docs))
+	for i, d := range docs {
+		osDocs[i] = d.ToOSDocument()
+	}
+	return b.client.BulkIndex(ctx, osDocs)
+}
+
+// DeleteByMongoIDs removes documents by MongoID via delete-by-query.
+func (b *OpenSearchBackend) DeleteByMongoIDs(ctx context.Context, mongoIDs []string) error {
+	return b.client.DeleteByMongoIDs(ctx, mongoIDs)
+}
+
+// Search runs a hybrid BM25 + k-NN query against OpenSearch.
+func (b *OpenSearchBackend) Search(ctx context.Context, req Request) (*Response, error) {
+	resp, err := b.client.HybridSearch(ctx, osSearchRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return fromOSSearchResponse(resp), nil
+}
+
+// Close closes the wrapped client.
+func (b *OpenSearchBackend) Close() error {
+	return b.client.Close()
+}
+
+// ToOSDocument converts a Document into the OpenSearch wire shape.
+func (d Document) ToOSDocument() opensearch.OSDocument {
+	authors := make([]opensearch.OSAuthor, len(d.Authors))
+	for i, a := range d.Authors {
+		authors[i] = opensearch.OSAuthor{
+			AuthorID:           a.AuthorID,
+			AuthorName:         a.AuthorName,
+			AuthorNameVariants: a.AuthorNameVariants,
+			AuthorPosition:     a.AuthorPosition,
+			AuthorAffiliation:  a.AuthorAffiliation,
+			AuthorEmail:        a.AuthorEmail,
+			HasMatchedProfile:  a.HasMatchedProfile,
+		}
+	}
+	return opensearch.OSDocument{
+		MongoID:            d.MongoID,
+		Title:              d.Title,
+		Abstract:           d.Abstract,
+		Authors:            authors,
+		AuthorNames:        d.AuthorNames,
+		AuthorNameVariants: d.AuthorNameVariants,
+		PublicationYear:    d.PublicationYear,
+		FieldAssociated:    d.FieldAssociated,
+		DocumentType:       d.DocumentType,
+		SubjectArea:        d.SubjectArea,
+		SubjectAreaCount:   d.SubjectAreaCount,
+		CitationCount:      d.CitationCount,
+		ReferenceCount:     d.ReferenceCount,
+		Embedding:          d.Embedding,
+	}
+}
+
+// DocumentFromOSDocument converts an opensearch.OSDocument into the
+// backend-neutral Document shape.
+func DocumentFromOSDocument(osDoc opensearch.OSDocument) Document {
+	authors := make([]Author, len(osDoc.Authors))
+	for i, a := range osDoc.Authors {
+		authors[i] = Author{
+			AuthorID:           a.AuthorID,
+			AuthorName:         a.AuthorName,
+			AuthorNameVariants: a.AuthorNameVariants,
+			AuthorPosition:     a.AuthorPosition,
+			AuthorAffiliation:  a.AuthorAffiliation,
+			AuthorEmail:        a.AuthorEmail,
+			HasMatchedProfile:  a.HasMatchedProfile,
+		}
+	}
+	return Document{
+		MongoID:            osDoc.MongoID,
+		Title:              osDoc.Title,
+		Abstract:           osDoc.Abstract,
+		Authors:            authors,
+		AuthorNames:        osDoc.AuthorNames,
+		AuthorNameVariants: osDoc.AuthorNameVariants,
+		PublicationYear:    osDoc.PublicationYear,
+		FieldAssociated:    osDoc.FieldAssociated,
+		DocumentType:       osDoc.DocumentType,
+		SubjectArea:        osDoc.SubjectArea,
+		SubjectAreaCount:   osDoc.SubjectAreaCount,
+		CitationCount:      osDoc.CitationCount,
+		ReferenceCount:     osDoc.ReferenceCount,
+		Embedding:          osDoc.Embedding,
+	}
+}
+
+// osSearchRequest converts a Request into an opensearch.SearchRequest.
+func osSearchRequest(req Request) opensearch.SearchRequest {
+	return opensearch.SearchRequest{
+		QueryText:      req.QueryText,
+		QueryEmbedding: req.QueryEmbedding,
+		TopK:           req.TopK,
+		Filters: opensearch.SearchFilters{
+			YearFrom:          req.Filters.YearFrom,
+			YearTo:            req.Filters.YearTo,
+			SubjectArea:       req.Filters.SubjectArea,
+			DocumentType:      req.Filters.DocumentType,
+			HasMatchedProfile: req.Filters.HasMatchedProfile,
+		},
+		RRFK:       req.RRFK,
+		BM25Weight: req.BM25Weight,
+		KNNWeight:  req.KNNWeight,
+	}
+}
+
+// fromOSSearchResponse converts an opensearch.SearchResponse into a
+// Response.
+func fromOSSearchResponse(resp *opensearch.SearchResponse) *Response {
+	hits := make([]Hit, len(resp.Hits))
+	for i, h := range resp.Hits {
+		hits[i] = Hit{
+			MongoID:    h.MongoID,
+			Source:     DocumentFromOSDocument(h.Source),
+			Score:      h.Score,
+			BM25Rank:   h.BM25Rank,
+			KNNRank:    h.KNNRank,
+			KNNScore:   h.KNNScore,
+			Highlights: h.Highlights,
+		}
+	}
+	return &Response{Hits: hits, BM25Only: resp.BM25Only, KNNOnly: resp.KNNOnly}
+}